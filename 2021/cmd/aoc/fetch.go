@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/client"
+)
+
+var fetchCommand = &command{
+	Name:  "fetch",
+	Usage: "fetch [-inputdir dir] [-session file] <year> <day>",
+	Run:   fetchInput,
+}
+
+// addSessionFlags adds the flags shared by all commands that talk to the
+// Advent of Code website.
+func addSessionFlags(fs *flag.FlagSet, sessionFile, baseURL *string) {
+	fs.StringVar(sessionFile, "session", client.DefaultSessionFile(), "read the session token from `file` unless $"+client.SessionEnv+" is set")
+	fs.StringVar(baseURL, "url", client.DefaultBaseURL, "base `url` of the Advent of Code website")
+}
+
+func newClient(sessionFile, baseURL string) (*client.Client, error) {
+	session, err := client.ReadSession(sessionFile)
+	if err != nil {
+		return nil, err
+	}
+
+	c := client.New(session)
+	c.BaseURL = baseURL
+
+	return c, nil
+}
+
+// inputCachePath returns where the downloaded input for a day is kept. The
+// inputs for this module's year go right into dir, where the solutions look
+// for them; those of other years go into a subdirectory named after the year.
+func inputCachePath(dir string, year, day int) string {
+	if year != aoc.Year {
+		dir = filepath.Join(dir, strconv.Itoa(year))
+	}
+	return aoc.InputPath(dir, day)
+}
+
+func fetchInput(args []string) error {
+	var sessionFile, baseURL string
+
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	dir := fs.String("inputdir", aoc.InputDir(), "store inputs in `dir`")
+	addSessionFlags(fs, &sessionFile, &baseURL)
+	args = parseInterspersed(fs, args)
+
+	if len(args) != 2 {
+		return errors.New("expected year and day")
+	}
+
+	year, err := strconv.Atoi(args[0])
+	if err != nil || year < 2015 {
+		return fmt.Errorf("invalid year %q", args[0])
+	}
+
+	day, err := strconv.Atoi(args[1])
+	if err != nil || day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %q", args[1])
+	}
+
+	path := inputCachePath(*dir, year, day)
+
+	// A cached input needs no session token.
+	if _, err := os.Stat(path); err == nil {
+		fmt.Println(path, "is already cached")
+		return nil
+	}
+
+	c, err := newClient(sessionFile, baseURL)
+	if err != nil {
+		return err
+	}
+
+	cached, err := c.FetchInput(year, day, path)
+	if err != nil {
+		return err
+	}
+
+	if cached {
+		fmt.Println(path, "is already cached")
+	} else {
+		fmt.Println("saved input to", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+)
+
+var genCommand = &command{
+	Name:  "gen",
+	Usage: "gen [-seed n] [-size n] <day>",
+	Run:   generate,
+}
+
+// generate writes a random input for a day to standard output.
+func generate(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	seed := fs.Int64("seed", 1, "seed for the random number generator")
+	size := fs.Int("size", 0, "size of the input, its meaning depends on the day (default: like the official input)")
+
+	days, err := selectDays(parseInterspersed(fs, args))
+	if err != nil {
+		return err
+	}
+
+	if len(days) != 1 {
+		return errors.New("expected a single day")
+	}
+
+	return days[0].Generate(os.Stdout, *seed, *size)
+}
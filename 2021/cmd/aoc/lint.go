@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+)
+
+var lintCommand = &command{
+	Name:  "lint",
+	Usage: "lint [-input file | -inputdir dir] all | <day>...",
+	Run:   lintDays,
+}
+
+// lintDays checks the inputs of the given days without solving them and
+// prints the problems found in the file:line:column format of compilers.
+func lintDays(args []string) error {
+	var in aoc.Input
+
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	in.RegisterFlags(fs)
+	args = parseInterspersed(fs, args)
+
+	days, err := selectDays(args)
+	if err != nil {
+		return err
+	}
+
+	if in.Path != "" && len(days) > 1 {
+		return errors.New("-input can only be used with a single day")
+	}
+
+	var failed int
+
+	for _, d := range days {
+		name := in.Name(d.Number)
+
+		input, err := in.ReadAll(d.Number)
+		if err != nil {
+			fmt.Printf("%s: %v\n", name, err)
+			failed++
+			continue
+		}
+
+		diags := d.Check(input)
+		for _, diag := range diags {
+			if diag.Line == 0 {
+				fmt.Printf("%s: %v\n", name, diag)
+			} else {
+				fmt.Printf("%s:%v\n", name, diag)
+			}
+		}
+
+		if len(diags) > 0 {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d inputs have problems", failed, len(days))
+	}
+
+	return nil
+}
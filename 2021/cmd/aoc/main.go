@@ -0,0 +1,75 @@
+// Command aoc runs the solutions for Advent of Code 2021.
+//
+// Usage:
+//
+//	aoc run all
+//	aoc run <day>...
+//	aoc lint all
+//	aoc lint <day>...
+//	aoc gen [-seed n] [-size n] <day>
+//	aoc play <day>
+//	aoc fetch <year> <day>
+//	aoc submit <day> <part>
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	_ "codeberg.org/mhofmann/adventofcode/internal/days"
+)
+
+type command struct {
+	Name  string
+	Usage string
+	Run   func(args []string) error
+}
+
+var commands = []*command{
+	runCommand,
+	lintCommand,
+	genCommand,
+	playCommand,
+	fetchCommand,
+	submitCommand,
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: aoc <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %s\n", cmd.Usage)
+	}
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := flag.Arg(0)
+
+	for _, cmd := range commands {
+		if cmd.Name != name {
+			continue
+		}
+
+		if err := cmd.Run(flag.Args()[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, "aoc "+name+":", err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n", name)
+	usage()
+	os.Exit(2)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+	"codeberg.org/mhofmann/adventofcode/internal/term"
+)
+
+var playCommand = &command{
+	Name:  "play",
+	Usage: "play [-input file | -inputdir dir] [-part n] [-param name=value]... [-timeout duration] [-delay duration] [-palette name] <day>",
+	Run:   playDay,
+}
+
+// playDay solves a day and plays the steps of its simulation as an animation
+// in the terminal. If standard output is not a terminal, the steps are
+// written as plain text instead.
+func playDay(args []string) error {
+	var (
+		in   aoc.Input
+		opts aoc.Options
+	)
+
+	fs := flag.NewFlagSet("play", flag.ExitOnError)
+	in.RegisterFlags(fs)
+	opts.RegisterFlags(fs)
+	delay := fs.Duration("delay", 100*time.Millisecond, "show each step for `duration` at first")
+	palette := fs.String("palette", "", "colour the steps with the palette `name`, or with a list of colours like #000000,#ffffff")
+	args = parseInterspersed(fs, args)
+
+	if err := opts.Check(); err != nil {
+		return err
+	}
+
+	if *delay < frame.MinDelay || *delay > frame.MaxDelay {
+		return fmt.Errorf("delay must be between %v and %v", frame.MinDelay, frame.MaxDelay)
+	}
+
+	days, err := selectDays(args)
+	if err != nil {
+		return err
+	}
+
+	if len(days) != 1 {
+		return errors.New("expected a single day")
+	}
+
+	var pal frame.Palette
+	if *palette != "" {
+		if pal, err = frame.ParsePalette(*palette); err != nil {
+			return err
+		}
+	}
+
+	anim := frame.Animation{Last: -1}
+	opts.Frames = &anim
+	opts.RequireFrames = true
+
+	r := days[0].Run(&in, opts)
+	if r.Failed() {
+		for _, res := range r.Parts {
+			aoc.PrintResult(os.Stderr, res)
+		}
+		return errors.New("cannot play a day that failed")
+	}
+
+	frames := anim.Frames()
+	if len(frames) == 0 {
+		return fmt.Errorf("day %d has no steps to play", r.Day)
+	}
+
+	out := int(os.Stdout.Fd())
+
+	if !term.IsTerminal(out) {
+		text := frame.NewText(os.Stdout)
+		for _, f := range frames {
+			text.Emit(f)
+		}
+		return text.Err()
+	}
+
+	p := &frame.Player{
+		Frames:  frames,
+		Palette: pal,
+		Delay:   *delay,
+		Size: func() (int, int) {
+			w, h, err := term.Size(out)
+			if err != nil || w == 0 || h == 0 {
+				return 80, 24
+			}
+			return w, h
+		},
+	}
+
+	// Keys come from standard input unless it holds the puzzle input
+	var keys chan byte
+
+	if in.Path != "-" && term.IsTerminal(int(os.Stdin.Fd())) {
+		restore, err := term.Raw(int(os.Stdin.Fd()))
+		if err != nil {
+			return err
+		}
+		defer restore()
+
+		keys = make(chan byte)
+		go readKeys(keys)
+	}
+
+	fmt.Print(term.AltScreen, term.HideCursor)
+	defer fmt.Print(term.Reset, term.ShowCursor, term.MainScreen)
+
+	return p.Play(os.Stdout, keys)
+}
+
+// readKeys sends the bytes read from standard input to keys until it ends.
+func readKeys(keys chan<- byte) {
+	defer close(keys)
+
+	var buf [16]byte
+
+	for {
+		n, err := os.Stdin.Read(buf[:])
+		for _, k := range buf[:n] {
+			keys <- k
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"image/png"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+)
+
+var runCommand = &command{
+	Name:  "run",
+	Usage: "run [-input file | -inputdir dir] [-part n] [-param name=value]... [-time] [-repeat n] [-j n] [-timeout duration] [-frames file] [-png file] [-gif file [-delay duration] [-steps first:last]] [-palette name] [-scale n] [-json] all | <day>...",
+	Run:   runDays,
+}
+
+// selectDays turns the command line arguments into a list of days. The
+// single argument "all" selects every registered day.
+func selectDays(args []string) ([]aoc.Day, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no days given")
+	}
+
+	if len(args) == 1 && args[0] == "all" {
+		return aoc.Days(), nil
+	}
+
+	var days []aoc.Day
+
+	for _, arg := range args {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid day %q", arg)
+		}
+
+		d, ok := aoc.Lookup(n)
+		if !ok {
+			return nil, fmt.Errorf("no solution for day %d", n)
+		}
+
+		days = append(days, d)
+	}
+
+	return days, nil
+}
+
+// parseInterspersed parses the flags in args like fs.Parse, but also those
+// that follow other arguments, and returns the other arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var rest []string
+
+	fs.Parse(args)
+
+	for fs.NArg() > 0 {
+		rest = append(rest, fs.Arg(0))
+		fs.Parse(fs.Args()[1:])
+	}
+
+	return rest
+}
+
+func printTimings(reports []aoc.Report) {
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Timing().Total() > reports[j].Timing().Total()
+	})
+
+	var sum aoc.Timing
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Day\tParse\tPart 1\tPart 2\tTotal\t")
+
+	for _, r := range reports {
+		t := r.Timing()
+		fmt.Fprintf(tw, "%d\t%v\t%v\t%v\t%v\t\n", r.Day, round(t.Parse), round(t.Part1), round(t.Part2), round(t.Total()))
+
+		sum.Parse += t.Parse
+		sum.Part1 += t.Part1
+		sum.Part2 += t.Part2
+	}
+
+	fmt.Fprintf(tw, "all\t%v\t%v\t%v\t%v\t\n", round(sum.Parse), round(sum.Part1), round(sum.Part2), round(sum.Total()))
+	tw.Flush()
+}
+
+func round(d time.Duration) time.Duration {
+	switch {
+	case d > time.Second:
+		return d.Round(time.Millisecond)
+	case d > time.Millisecond:
+		return d.Round(time.Microsecond)
+	default:
+		return d
+	}
+}
+
+// runParallel solves days with up to jobs of them at the same time and sends
+// their reports in the order of days, each as soon as it and all before it
+// are done. A day that timed out keeps its job until its solver returns, so
+// that no more than jobs solvers ever run at once.
+func runParallel(days []aoc.Day, in *aoc.Input, opts aoc.Options, jobs int) <-chan aoc.Report {
+	var (
+		sem     = make(chan struct{}, jobs)
+		reports = make([]chan aoc.Report, len(days))
+		out     = make(chan aoc.Report)
+	)
+
+	for i, d := range days {
+		reports[i] = make(chan aoc.Report, 1)
+
+		go func(d aoc.Day, report chan<- aoc.Report) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			r := d.Run(in, opts)
+			report <- r
+			r.Wait()
+		}(d, reports[i])
+	}
+
+	go func() {
+		for _, report := range reports {
+			out <- <-report
+		}
+		close(out)
+	}()
+
+	return out
+}
+
+func runDays(args []string) error {
+	var (
+		in   aoc.Input
+		opts aoc.Options
+	)
+
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	in.RegisterFlags(fs)
+	opts.RegisterFlags(fs)
+	timing := fs.Bool("time", false, "print a table of the time spent parsing and solving both parts")
+	fs.IntVar(&opts.Repeat, "repeat", 1, "solve each day `n` times and report the median durations")
+	jobs := fs.Int("j", 1, "solve up to `n` days at the same time")
+	jsonOut := fs.Bool("json", false, "write one JSON object per part instead of text")
+	frames := fs.String("frames", "", "write the steps of the simulation to `file` as text")
+	pngOut := fs.String("png", "", "write the last step of the simulation to `file` as a PNG image")
+	gifOut := fs.String("gif", "", "write the steps of the simulation to `file` as an animated GIF")
+	delay := fs.Duration("delay", 100*time.Millisecond, "show each step of the animation for `duration`")
+	steps := fs.String("steps", "", "animate only the steps in the range `first:last`, where either may be left out")
+	palette := fs.String("palette", "", "colour the image with the palette `name`, or with a list of colours like #000000,#ffffff")
+	scale := fs.Int("scale", 1, "draw each cell of the image as a square of `n` pixels")
+	args = parseInterspersed(fs, args)
+
+	if err := opts.Check(); err != nil {
+		return err
+	}
+
+	if *jobs < 1 {
+		return fmt.Errorf("invalid number of jobs %d", *jobs)
+	}
+
+	days, err := selectDays(args)
+	if err != nil {
+		return err
+	}
+
+	if in.Path != "" && len(days) > 1 {
+		return errors.New("-input can only be used with a single day")
+	}
+
+	var pal frame.Palette
+	if *palette != "" {
+		if pal, err = frame.ParsePalette(*palette); err != nil {
+			return err
+		}
+	}
+
+	if *scale < 1 {
+		return fmt.Errorf("invalid scale %d", *scale)
+	}
+
+	if (*frames != "" || *pngOut != "" || *gifOut != "") && len(days) > 1 {
+		return errors.New("-frames, -png and -gif can only be used with a single day")
+	}
+
+	if *delay < 0 {
+		return fmt.Errorf("invalid delay %v", *delay)
+	}
+
+	anim := frame.Animation{Last: -1}
+	if *steps != "" {
+		if anim.First, anim.Last, err = parseSteps(*steps); err != nil {
+			return err
+		}
+	}
+
+	// Solving a day takes long enough to find out it has no frames first
+	opts.RequireFrames = *pngOut != "" || *gifOut != ""
+
+	var sinks []frame.Sink
+
+	if *frames != "" {
+		f, err := os.Create(*frames)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		sink := frame.NewText(f)
+		defer func() {
+			if err := sink.Err(); err != nil {
+				fmt.Fprintln(os.Stderr, "aoc run: frames:", err)
+			}
+		}()
+
+		sinks = append(sinks, sink)
+	}
+
+	var last frame.Last
+
+	if *pngOut != "" {
+		sinks = append(sinks, &last)
+	}
+
+	if *gifOut != "" {
+		sinks = append(sinks, &anim)
+	}
+
+	switch len(sinks) {
+	case 0:
+	case 1:
+		opts.Frames = sinks[0]
+	default:
+		opts.Frames = frame.Tee(sinks...)
+	}
+
+	var (
+		failed  int
+		reports []aoc.Report
+	)
+
+	enc := json.NewEncoder(os.Stdout)
+
+	for r := range runParallel(days, &in, opts, *jobs) {
+		if r.Failed() {
+			failed++
+		}
+
+		if *jsonOut {
+			for _, res := range r.Parts {
+				if err := enc.Encode(res); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		fmt.Printf("Day %02d\n", r.Day)
+
+		for _, res := range r.Parts {
+			aoc.PrintResult(os.Stdout, res)
+		}
+
+		if !r.Failed() {
+			reports = append(reports, r)
+		}
+	}
+
+	if *pngOut != "" && failed == 0 {
+		if err := writePNG(*pngOut, last.Frame(), pal, *scale); err != nil {
+			return err
+		}
+	}
+
+	if *gifOut != "" && failed == 0 {
+		var buf bytes.Buffer
+		if err := frame.WriteGIF(&buf, anim.Frames(), pal, *scale, *delay); err != nil {
+			return fmt.Errorf("cannot animate frames: %w", err)
+		}
+
+		if err := os.WriteFile(*gifOut, buf.Bytes(), 0o666); err != nil {
+			return err
+		}
+	}
+
+	if *timing && !*jsonOut && len(reports) > 0 {
+		fmt.Println()
+		printTimings(reports)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d days failed", failed, len(days))
+	}
+
+	return nil
+}
+
+// writePNG writes f to the file name as a PNG image.
+func writePNG(name string, f *frame.Frame, pal frame.Palette, scale int) error {
+	if f == nil {
+		return errors.New("no frames to write to the PNG image")
+	}
+
+	img, err := f.Image(pal, scale)
+	if err != nil {
+		return fmt.Errorf("cannot draw frame: %w", err)
+	}
+
+	out, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(out, img); err != nil {
+		out.Close()
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return out.Close()
+}
+
+// parseSteps parses a range of steps like "10:20", where a missing first step
+// stands for 0 and a missing last step for -1, the end of the simulation.
+func parseSteps(s string) (first, last int, err error) {
+	from, to, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid range of steps %q, expected first:last", s)
+	}
+
+	last = -1
+
+	if from != "" {
+		if first, err = strconv.Atoi(from); err != nil || first < 0 {
+			return 0, 0, fmt.Errorf("invalid first step %q", from)
+		}
+	}
+
+	if to != "" {
+		if last, err = strconv.Atoi(to); err != nil || last < first {
+			return 0, 0, fmt.Errorf("invalid last step %q", to)
+		}
+	}
+
+	return first, last, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/client"
+)
+
+var submitCommand = &command{
+	Name:  "submit",
+	Usage: "submit [-input file | -inputdir dir] [-session file] [-log file] <day> <part>",
+	Run:   submitAnswer,
+}
+
+func submitAnswer(args []string) error {
+	var (
+		in                   aoc.Input
+		sessionFile, baseURL string
+	)
+
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	in.RegisterFlags(fs)
+	addSessionFlags(fs, &sessionFile, &baseURL)
+	logFile := fs.String("log", "", "record submitted answers in `file` (default: answers.jsonl in the input directory)")
+	args = parseInterspersed(fs, args)
+
+	if *logFile == "" {
+		*logFile = filepath.Join(in.Dir, "answers.jsonl")
+	}
+
+	if len(args) != 2 {
+		return errors.New("expected day and part")
+	}
+
+	days, err := selectDays(args[:1])
+	if err != nil {
+		return err
+	}
+	d := days[0]
+
+	part, err := strconv.Atoi(args[1])
+	if err != nil || part < 1 || part > 2 {
+		return fmt.Errorf("invalid part %q", args[1])
+	}
+
+	res := d.Run(&in, aoc.Options{Part: part}).Parts[0]
+	if res.Error != "" {
+		return errors.New(res.Error)
+	}
+
+	answer := res.Answer
+
+	if answer == "" || strings.Contains(answer, "\n") {
+		return fmt.Errorf("answer %q cannot be submitted", answer)
+	}
+
+	log, err := client.LoadAnswerLog(*logFile)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if err = log.Check(aoc.Year, d.Number, part, answer, now); err != nil {
+		return fmt.Errorf("not submitting %s: %w", answer, err)
+	}
+
+	c, err := newClient(sessionFile, baseURL)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Submit(aoc.Year, d.Number, part, answer)
+	if err != nil {
+		return err
+	}
+
+	attempt := client.Attempt{
+		Time:    now,
+		Year:    aoc.Year,
+		Day:     d.Number,
+		Part:    part,
+		Answer:  answer,
+		Verdict: resp.Verdict,
+		Hint:    resp.Hint,
+	}
+
+	if resp.Wait > 0 {
+		attempt.WaitUntil = now.Add(resp.Wait)
+	}
+
+	if err = log.Append(attempt); err != nil {
+		return err
+	}
+
+	fmt.Printf("Day %d part %d: %s ", d.Number, part, answer)
+
+	switch resp.Verdict {
+	case client.Correct, client.Wrong:
+		if resp.Hint != client.NoHint {
+			fmt.Printf("is %s, %s\n", resp.Verdict, resp.Hint)
+		} else {
+			fmt.Printf("is %s\n", resp.Verdict)
+		}
+	case client.TooSoon, client.AlreadySolved:
+		fmt.Printf("was not checked: %s\n", resp.Verdict)
+	default:
+		fmt.Printf("got an unexpected response: %s\n", resp.Message)
+	}
+
+	if resp.Wait > 0 {
+		fmt.Printf("Wait %v before the next attempt\n", resp.Wait)
+	}
+
+	return nil
+}
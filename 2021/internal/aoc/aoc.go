@@ -0,0 +1,113 @@
+// Package aoc keeps track of the solutions for the individual days so that
+// they can be run from a single command.
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+)
+
+// Year is the event all solutions in this module belong to.
+const Year = 2021
+
+// Answers holds the answers to both parts of a puzzle.
+type Answers struct {
+	Part1, Part2 string
+}
+
+// Puzzle is the parsed input of a single day.
+type Puzzle interface {
+	Part1() (string, error)
+	Part2() (string, error)
+}
+
+// Cancelable is implemented by puzzles whose parts can run for a long time
+// on unusual inputs. The parts give up with the error of ctx once it is done.
+// Part1 and Part2 are equivalent to passing context.Background().
+type Cancelable interface {
+	Part1Context(ctx context.Context) (string, error)
+	Part2Context(ctx context.Context) (string, error)
+}
+
+// Recordable is implemented by puzzles that simulate something step by step.
+// Once they have a sink, their parts emit a frame for each step into it.
+type Recordable interface {
+	Record(sink frame.Sink)
+}
+
+// Parser reads the puzzle input of a single day.
+type Parser func(r io.Reader) (Puzzle, error)
+
+// Solve parses the input read from r and solves both parts of the puzzle.
+func Solve(r io.Reader, parse Parser) (Answers, error) {
+	var a Answers
+
+	p, err := parse(r)
+	if err != nil {
+		return a, err
+	}
+
+	if a.Part1, err = p.Part1(); err != nil {
+		return a, fmt.Errorf("part 1: %w", err)
+	}
+
+	if a.Part2, err = p.Part2(); err != nil {
+		return a, fmt.Errorf("part 2: %w", err)
+	}
+
+	return a, nil
+}
+
+// Day is the solution for the puzzle of one day, as made available by
+// Register.
+type Day struct {
+	Number int
+	Parse  Parser
+	Lint   Linter    // Optional
+	Gen    Generator // Optional
+}
+
+// Solve solves both parts of the puzzle for the input read from r.
+func (d Day) Solve(r io.Reader) (Answers, error) {
+	return Solve(r, d.Parse)
+}
+
+var days = make(map[int]Day)
+
+// Register makes the solution for a day available to Lookup and Days. It is
+// meant to be called from the init function of the day's package and panics
+// if the day is registered twice.
+func Register(d Day) {
+	if d.Number < 1 || d.Number > 25 {
+		panic(fmt.Sprintf("aoc: invalid day %d", d.Number))
+	}
+
+	if _, dup := days[d.Number]; dup {
+		panic(fmt.Sprintf("aoc: day %d registered twice", d.Number))
+	}
+
+	days[d.Number] = d
+}
+
+// Lookup returns the solution registered for day n, if there is one.
+func Lookup(n int) (Day, bool) {
+	d, ok := days[n]
+	return d, ok
+}
+
+// Days returns all registered days in ascending order.
+func Days() []Day {
+	list := make([]Day, 0, len(days))
+
+	for _, d := range days {
+		list = append(list, d)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Number < list[j].Number })
+
+	return list
+}
@@ -0,0 +1,34 @@
+// Package aoctest helps testing the solutions of individual days.
+package aoctest
+
+import (
+	"bytes"
+	"testing"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+)
+
+// Generate returns the puzzle parsed from an input generated for day with
+// seed and size, as registered by the day's package. P is the puzzle type of
+// the day. Any failure ends the test.
+func Generate[P aoc.Puzzle](t testing.TB, day int, seed int64, size int) P {
+	t.Helper()
+
+	d, ok := aoc.Lookup(day)
+	if !ok {
+		t.Fatalf("no solution for day %d", day)
+	}
+
+	var buf bytes.Buffer
+
+	if err := d.Generate(&buf, seed, size); err != nil {
+		t.Fatalf("seed %d: %v", seed, err)
+	}
+
+	p, err := d.Parse(&buf)
+	if err != nil {
+		t.Fatalf("seed %d: %v", seed, err)
+	}
+
+	return p.(P)
+}
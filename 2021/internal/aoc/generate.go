@@ -0,0 +1,32 @@
+package aoc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// Generator writes a valid puzzle input of the given size to w, using only
+// rnd as its source of randomness so that the same seed produces the same
+// input. What size means depends on the day, for example the number of lines
+// or the width of a grid. A size of 0 selects the size of the official
+// inputs.
+type Generator func(w *bufio.Writer, rnd *rand.Rand, size int)
+
+// Generate writes a random input for the day to w.
+func (d Day) Generate(w io.Writer, seed int64, size int) error {
+	if d.Gen == nil {
+		return fmt.Errorf("no generator for day %d", d.Number)
+	}
+
+	if size < 0 {
+		return errors.New("negative size")
+	}
+
+	bw := bufio.NewWriter(w)
+	d.Gen(bw, rand.New(rand.NewSource(seed)), size)
+
+	return bw.Flush()
+}
@@ -0,0 +1,93 @@
+package aoc
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// InputDirEnv names the environment variable that overrides DefaultInputDir.
+const InputDirEnv = "AOC_INPUT_DIR"
+
+// DefaultInputDir is the directory searched for puzzle inputs unless
+// InputDirEnv or the -inputdir flag say otherwise.
+const DefaultInputDir = "input"
+
+// Input describes where the puzzle input comes from. If Path is empty, the
+// input of a day is read from the file dayNN.txt below Dir. A Path of "-"
+// stands for standard input.
+type Input struct {
+	Path string
+	Dir  string
+}
+
+// InputDir returns the input directory from InputDirEnv or DefaultInputDir.
+func InputDir() string {
+	if dir := os.Getenv(InputDirEnv); dir != "" {
+		return dir
+	}
+	return DefaultInputDir
+}
+
+// RegisterFlags adds the -input and -inputdir flags to fs.
+func (in *Input) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&in.Path, "input", "", "read the puzzle input from `file` (- for stdin)")
+	fs.StringVar(&in.Dir, "inputdir", InputDir(), "look for dayNN.txt input files in `dir`")
+}
+
+// InputPath returns the location of the input file for day below dir.
+func InputPath(dir string, day int) string {
+	return filepath.Join(dir, fmt.Sprintf("day%02d.txt", day))
+}
+
+// Name returns the file name the input of day is read from.
+func (in *Input) Name(day int) string {
+	if in.Path == "-" {
+		return "stdin"
+	}
+
+	if in.Path != "" {
+		return in.Path
+	}
+
+	return InputPath(in.Dir, day)
+}
+
+// Open opens the input for day. The caller must close it.
+func (in *Input) Open(day int) (io.ReadCloser, error) {
+	if in.Path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	name := in.Name(day)
+
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("input file %s does not exist", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot open input file: %w", err)
+	}
+
+	return f, nil
+}
+
+// ReadAll returns the complete input for day.
+func (in *Input) ReadAll(day int) ([]byte, error) {
+	f, err := in.Open(day)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", in.Name(day), err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,21 @@
+package aoc
+
+import "codeberg.org/mhofmann/adventofcode/internal/lint"
+
+// Linter checks the puzzle input of a single day against its grammar without
+// solving it.
+type Linter func(input []byte) []lint.Diagnostic
+
+// Check reports the problems in input, both those common to all inputs and
+// those found by the day's linter, ordered by their position.
+func (d Day) Check(input []byte) []lint.Diagnostic {
+	diags := lint.Common(input)
+
+	if d.Lint != nil && len(input) > 0 {
+		diags = append(diags, d.Lint(input)...)
+	}
+
+	lint.Sort(diags)
+
+	return diags
+}
@@ -0,0 +1,74 @@
+package aoc
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Run solves day with the input described by in. If the input cannot be
+// read, the error is reported for all selected parts.
+func (d Day) Run(in *Input, opts Options) Report {
+	input, err := in.ReadAll(d.Number)
+	if err != nil {
+		r := newReport(d.Number, opts.Part)
+		r.fail(err.Error())
+		return r
+	}
+
+	return d.Measure(input, opts)
+}
+
+// PrintResult writes the answer to one part of a puzzle to w, or the error
+// that kept it from being solved, followed by a warning if there is one.
+// Answers that span multiple lines start on a line of their own.
+func PrintResult(w io.Writer, r Result) {
+	switch {
+	case r.Error != "":
+		fmt.Fprintf(w, "Part %d: error: %s\n", r.Part, r.Error)
+	case strings.Contains(r.Answer, "\n"):
+		fmt.Fprintf(w, "Part %d:\n%s\n", r.Part, strings.TrimSuffix(r.Answer, "\n"))
+	default:
+		fmt.Fprintf(w, "Part %d: %s\n", r.Part, r.Answer)
+	}
+
+	if r.Warning != "" {
+		fmt.Fprintf(w, "Part %d: warning: %s\n", r.Part, r.Warning)
+	}
+}
+
+// Main implements the command line interface of the single day commands.
+// The solution for day must have been registered before.
+func Main(day int) {
+	d, ok := Lookup(day)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "no solution for day %d\n", day)
+		os.Exit(1)
+	}
+
+	var (
+		in   Input
+		opts Options
+	)
+
+	in.RegisterFlags(flag.CommandLine)
+	opts.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := opts.Check(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	r := d.Run(&in, opts)
+
+	for _, res := range r.Parts {
+		PrintResult(os.Stdout, res)
+	}
+
+	if r.Failed() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,117 @@
+package aoc
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+)
+
+// Configurable is implemented by puzzles with parameters that can be changed
+// from the command line, like the number of simulated steps. Params defines
+// the parameters as flags on fs, with the values set by the parser as
+// defaults.
+type Configurable interface {
+	Params(fs *flag.FlagSet)
+}
+
+// Params maps parameter names to values. It implements flag.Value, so that
+// parameters can be given as repeated name=value flags.
+type Params map[string]string
+
+func (p Params) String() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		names[i] = name + "=" + p[name]
+	}
+
+	return strings.Join(names, ",")
+}
+
+func (p Params) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("parameter %q is not of the form name=value", s)
+	}
+
+	p[name] = value
+
+	return nil
+}
+
+// Apply sets the parameters of puzzle.
+func (p Params) Apply(puzzle Puzzle) error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	c, ok := puzzle.(Configurable)
+	if !ok {
+		return errors.New("puzzle has no parameters")
+	}
+
+	fs := flag.NewFlagSet("params", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	c.Params(fs)
+
+	for name, value := range p {
+		if fs.Lookup(name) == nil {
+			var names []string
+			fs.VisitAll(func(f *flag.Flag) { names = append(names, f.Name) })
+
+			return fmt.Errorf("unknown parameter %q, known are: %s", name, strings.Join(names, ", "))
+		}
+
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("parameter %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Options control how a day is run.
+type Options struct {
+	Part    int           // Solve only this part, or both if zero
+	Repeat  int           // Solve this many times and report the median durations
+	Timeout time.Duration // Give up on the day after this long, unless zero
+	Params  Params
+	Frames  frame.Sink // Receives the frames of Recordable puzzles once, optional
+
+	// Fail days that are not Recordable before solving them
+	RequireFrames bool
+}
+
+// RegisterFlags adds the -part, -param and -timeout flags to fs.
+func (o *Options) RegisterFlags(fs *flag.FlagSet) {
+	if o.Params == nil {
+		o.Params = make(Params)
+	}
+
+	fs.IntVar(&o.Part, "part", 0, "solve only part `n`")
+	fs.Var(o.Params, "param", "set puzzle parameter to a value, given as `name=value`")
+	fs.DurationVar(&o.Timeout, "timeout", 0, "give up on a day after `duration`, 0 means never")
+}
+
+// Check reports invalid options.
+func (o *Options) Check() error {
+	if o.Part < 0 || o.Part > 2 {
+		return fmt.Errorf("invalid part %d", o.Part)
+	}
+
+	if o.Timeout < 0 {
+		return fmt.Errorf("invalid timeout %v", o.Timeout)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package aoc
+
+import (
+	"time"
+)
+
+// Result is the outcome of solving one part of a puzzle.
+type Result struct {
+	Year     int           `json:"year"`
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+	Error    string        `json:"error,omitempty"`
+	Warning  string        `json:"warning,omitempty"`
+}
+
+// Warning is returned by the parts of puzzles along with an answer that may
+// need a closer look, like letters drawn with dots that could not be read.
+// Such an answer is still reported, but with the warning.
+type Warning string
+
+func (w Warning) Error() string {
+	return string(w)
+}
+
+// Report collects the results for the solved parts of a day, along with the
+// time it took to parse the input.
+type Report struct {
+	Day   int
+	Parse time.Duration
+	Parts []Result
+
+	done <-chan struct{} // Closed once no phase is running anymore
+}
+
+// Wait blocks until all phases of solving the puzzle have returned, even
+// those that were given up on after a timeout but keep running because they
+// do not watch for it.
+func (r *Report) Wait() {
+	if r.done != nil {
+		<-r.done
+	}
+}
+
+// newReport returns a report for the given part of day, or for both parts if
+// part is zero.
+func newReport(day, part int) Report {
+	r := Report{Day: day}
+
+	for i := 1; i <= 2; i++ {
+		if part == 0 || part == i {
+			r.Parts = append(r.Parts, Result{Year: Year, Day: day, Part: i})
+		}
+	}
+
+	return r
+}
+
+// fail reports err for all parts.
+func (r *Report) fail(err string) {
+	for i := range r.Parts {
+		r.Parts[i].Error = err
+	}
+}
+
+func (r *Report) Timing() Timing {
+	t := Timing{Parse: r.Parse}
+
+	for _, res := range r.Parts {
+		if res.Part == 1 {
+			t.Part1 = res.Duration
+		} else {
+			t.Part2 = res.Duration
+		}
+	}
+
+	return t
+}
+
+// Failed reports whether any part could not be solved.
+func (r *Report) Failed() bool {
+	for _, res := range r.Parts {
+		if res.Error != "" {
+			return true
+		}
+	}
+	return false
+}
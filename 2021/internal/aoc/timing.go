@@ -0,0 +1,197 @@
+package aoc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Timing holds how long the phases of solving a puzzle took.
+type Timing struct {
+	Parse time.Duration
+	Part1 time.Duration
+	Part2 time.Duration
+}
+
+func (t Timing) Total() time.Duration {
+	return t.Parse + t.Part1 + t.Part2
+}
+
+func median(d []time.Duration) time.Duration {
+	sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+	return d[len(d)/2]
+}
+
+// recovered turns a panic into an error stored in err, so that a bug in one
+// day does not take down a run of all days.
+func recovered(err *error) {
+	if v := recover(); v != nil {
+		*err = fmt.Errorf("panic: %v", v)
+	}
+}
+
+// withContext calls f in a goroutine of its own and returns its result, or
+// the error of ctx if ctx is done before f returns. In that case f keeps
+// running in the background unless it watches ctx itself; running counts the
+// goroutine until f returns.
+func withContext[T any](ctx context.Context, running *sync.WaitGroup, f func() (T, error)) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	type result struct {
+		v   T
+		err error
+	}
+
+	ch := make(chan result, 1)
+
+	running.Add(1)
+
+	go func() {
+		defer running.Done()
+
+		var r result
+		defer func() { ch <- r }()
+		defer recovered(&r.err)
+		r.v, r.err = f()
+	}()
+
+	select {
+	case r := <-ch:
+		return r.v, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+func parse(ctx context.Context, running *sync.WaitGroup, d Day, input []byte) (Puzzle, error) {
+	return withContext(ctx, running, func() (Puzzle, error) {
+		return d.Parse(bytes.NewReader(input))
+	})
+}
+
+// solve solves one part of p, passing ctx on if p is Cancelable.
+func solve(ctx context.Context, running *sync.WaitGroup, p Puzzle, part int) (string, error) {
+	f := p.Part1
+	if part == 2 {
+		f = p.Part2
+	}
+
+	if c, ok := p.(Cancelable); ok {
+		f = func() (string, error) { return c.Part1Context(ctx) }
+		if part == 2 {
+			f = func() (string, error) { return c.Part2Context(ctx) }
+		}
+	}
+
+	return withContext(ctx, running, f)
+}
+
+// Measure solves the puzzle for input as described by opts and reports the
+// answers along with the median duration of each phase. The parts are solved
+// independently, so an error in one does not keep the other from running.
+func (d Day) Measure(input []byte, opts Options) Report {
+	ctx := context.Background()
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	return d.MeasureContext(ctx, input, opts)
+}
+
+// MeasureContext is like Measure, but gives up once ctx is done. Phases that
+// are still running then are reported as failed, and the report's Wait
+// method waits for them to return.
+func (d Day) MeasureContext(ctx context.Context, input []byte, opts Options) (r Report) {
+	r = newReport(d.Number, opts.Part)
+
+	var running sync.WaitGroup
+
+	defer func() {
+		done := make(chan struct{})
+		go func() {
+			running.Wait()
+			close(done)
+		}()
+		r.done = done
+	}()
+
+	// Describe timeouts by what was asked for rather than by the context
+	describe := func(err error) string {
+		if errors.Is(err, context.DeadlineExceeded) && opts.Timeout > 0 {
+			return fmt.Sprintf("timed out after %v", opts.Timeout)
+		}
+		return err.Error()
+	}
+
+	repeat := opts.Repeat
+	if repeat < 1 {
+		repeat = 1
+	}
+
+	var times [3][]time.Duration
+
+	for i := 0; i < repeat; i++ {
+		start := time.Now()
+
+		p, err := parse(ctx, &running, d, input)
+		if err != nil {
+			r.fail("parse: " + describe(err))
+			return r
+		}
+
+		times[0] = append(times[0], time.Since(start))
+
+		if err := opts.Params.Apply(p); err != nil {
+			r.fail(err.Error())
+			return r
+		}
+
+		rec, ok := p.(Recordable)
+		if !ok && opts.RequireFrames {
+			r.fail("the puzzle does not simulate anything step by step")
+			return r
+		}
+
+		// Record the steps once, not again for every repetition
+		if ok && opts.Frames != nil && i == 0 {
+			rec.Record(opts.Frames)
+		}
+
+		for j := range r.Parts {
+			start = time.Now()
+			answer, err := solve(ctx, &running, p, r.Parts[j].Part)
+			times[j+1] = append(times[j+1], time.Since(start))
+
+			var warning Warning
+
+			switch {
+			case errors.As(err, &warning):
+				r.Parts[j].Answer = answer
+				r.Parts[j].Warning = string(warning)
+			case err != nil:
+				r.Parts[j].Error = describe(err)
+			default:
+				r.Parts[j].Answer = answer
+			}
+		}
+	}
+
+	r.Parse = median(times[0])
+
+	for j := range r.Parts {
+		r.Parts[j].Duration = median(times[j+1])
+	}
+
+	return r
+}
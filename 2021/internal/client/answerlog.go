@@ -0,0 +1,139 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Attempt is a single submitted answer as recorded in the answer log.
+type Attempt struct {
+	Time      time.Time `json:"time"`
+	Year      int       `json:"year"`
+	Day       int       `json:"day"`
+	Part      int       `json:"part"`
+	Answer    string    `json:"answer"`
+	Verdict   Verdict   `json:"verdict"`
+	Hint      Hint      `json:"hint,omitempty"`
+	WaitUntil time.Time `json:"wait_until"`
+}
+
+// AnswerLog keeps all attempts in a file with one JSON object per line.
+type AnswerLog struct {
+	Path     string
+	Attempts []Attempt
+}
+
+// LoadAnswerLog reads the log at path. A missing file is an empty log.
+func LoadAnswerLog(path string) (*AnswerLog, error) {
+	l := &AnswerLog{Path: path}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		var a Attempt
+		if err := json.Unmarshal(scanner.Bytes(), &a); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		l.Attempts = append(l.Attempts, a)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Check returns an error if submitting answer at the time now is pointless:
+// because the part is already solved, the answer is known to be wrong, a
+// previous hint rules it out, or the website still wants us to wait.
+func (l *AnswerLog) Check(year, day, part int, answer string, now time.Time) error {
+	num, numErr := strconv.ParseInt(answer, 10, 64)
+
+	for _, a := range l.Attempts {
+		if now.Before(a.WaitUntil) {
+			return fmt.Errorf("wait until %s before submitting again", a.WaitUntil.Format(time.Kitchen))
+		}
+
+		if a.Year != year || a.Day != day || a.Part != part {
+			continue
+		}
+
+		if a.Verdict == Correct {
+			if a.Answer == answer {
+				return fmt.Errorf("%s was already accepted", answer)
+			}
+			return fmt.Errorf("part already solved with %s", a.Answer)
+		}
+
+		if a.Verdict != Wrong {
+			continue
+		}
+
+		if a.Answer == answer {
+			return fmt.Errorf("%s was already rejected", answer)
+		}
+
+		prev, err := strconv.ParseInt(a.Answer, 10, 64)
+		if err != nil || numErr != nil {
+			continue
+		}
+
+		if a.Hint == TooHigh && num >= prev {
+			return fmt.Errorf("%s is not below %s, which was too high", answer, a.Answer)
+		}
+
+		if a.Hint == TooLow && num <= prev {
+			return fmt.Errorf("%s is not above %s, which was too low", answer, a.Answer)
+		}
+	}
+
+	return nil
+}
+
+// Append records an attempt in memory and in the log file.
+func (l *AnswerLog) Append(a Attempt) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(l.Path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	l.Attempts = append(l.Attempts, a)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAnswerLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.jsonl")
+	now := time.Date(2021, 12, 7, 6, 0, 0, 0, time.UTC)
+
+	l, err := LoadAnswerLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := []Attempt{
+		{Time: now, Year: 2021, Day: 7, Part: 1, Answer: "37", Verdict: Correct},
+		{Time: now, Year: 2021, Day: 7, Part: 2, Answer: "170", Verdict: Wrong, Hint: TooHigh, WaitUntil: now.Add(time.Minute)},
+		{Time: now, Year: 2021, Day: 7, Part: 2, Answer: "100", Verdict: Wrong, Hint: TooLow},
+	}
+
+	for _, a := range attempts {
+		if err = l.Append(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Reload to make sure the log survives the round trip.
+	if l, err = LoadAnswerLog(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(l.Attempts) != len(attempts) {
+		t.Fatalf("got %d attempts, want %d", len(l.Attempts), len(attempts))
+	}
+
+	later := now.Add(2 * time.Minute)
+
+	tests := []struct {
+		part   int
+		answer string
+		now    time.Time
+		ok     bool
+	}{
+		{1, "37", later, false}, // already accepted
+		{1, "38", later, false}, // already solved
+		{2, "168", now, false},  // cooldown
+		{2, "168", later, true},
+		{2, "170", later, false}, // already rejected
+		{2, "171", later, false}, // above too high
+		{2, "99", later, false},  // below too low
+		{2, "abc", later, true},  // not comparable
+	}
+
+	for _, test := range tests {
+		err := l.Check(2021, 7, test.part, test.answer, test.now)
+		if (err == nil) != test.ok {
+			t.Errorf("Check(part %d, %s) = %v, want ok = %v", test.part, test.answer, err, test.ok)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Package client talks to the Advent of Code website on behalf of a logged in
+// user.
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	UserAgent      = "codeberg.org/mhofmann/adventofcode"
+)
+
+// SessionEnv names the environment variable holding the session token. It
+// takes precedence over the session file.
+const SessionEnv = "AOC_SESSION"
+
+// DefaultSessionFile returns the file the session token is read from if
+// SessionEnv is not set.
+func DefaultSessionFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "aoc", "session")
+}
+
+// ReadSession returns the session token from SessionEnv or, if that is not
+// set, from the given file.
+func ReadSession(file string) (string, error) {
+	if s := strings.TrimSpace(os.Getenv(SessionEnv)); s != "" {
+		return s, nil
+	}
+
+	if file == "" {
+		return "", fmt.Errorf("no session token: set %s", SessionEnv)
+	}
+
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("no session token: set %s or write it to %s", SessionEnv, file)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	s := strings.TrimSpace(string(data))
+	if s == "" {
+		return "", fmt.Errorf("%s: empty session token", file)
+	}
+
+	return s, nil
+}
+
+type Client struct {
+	BaseURL string
+	Session string
+	HTTP    *http.Client
+}
+
+func New(session string) *Client {
+	return &Client{
+		BaseURL: DefaultBaseURL,
+		Session: session,
+		HTTP:    http.DefaultClient,
+	}
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	req.Header.Set("User-Agent", UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(body))
+		if len(msg) > 200 {
+			msg = msg[:200] + "..."
+		}
+
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Path, resp.Status, msg)
+	}
+
+	return body, nil
+}
+
+// Input downloads the puzzle input for a day.
+func (c *Client) Input(year, day int) ([]byte, error) {
+	url := fmt.Sprintf("%s/%d/day/%d/input", c.BaseURL, year, day)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(req)
+}
+
+// FetchInput stores the puzzle input for a day in the file path. If the file
+// already exists, nothing is downloaded and FetchInput reports it as cached.
+func (c *Client) FetchInput(year, day int, path string) (cached bool, err error) {
+	if _, err := os.Stat(path); err == nil {
+		return true, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+
+	data, err := c.Input(year, day)
+	if err != nil {
+		return false, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, err
+	}
+
+	// Write to a temporary file first, so that an interrupted download does
+	// not leave a truncated input behind that looks cached.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return false, err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return false, err
+	}
+
+	return false, os.Rename(tmp.Name(), path)
+}
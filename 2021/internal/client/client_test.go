@@ -0,0 +1,97 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := New("secret")
+	c.BaseURL = srv.URL
+	c.HTTP = srv.Client()
+
+	return c
+}
+
+func TestFetchInput(t *testing.T) {
+	var requests int
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path != "/2021/day/7/input" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			t.Errorf("missing session cookie")
+		}
+
+		w.Write([]byte("16,1,2,0,4,2,7,1,2,14\n"))
+	})
+
+	path := filepath.Join(t.TempDir(), "input", "day07.txt")
+
+	for i, wantCached := range []bool{false, true} {
+		cached, err := c.FetchInput(2021, 7, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if cached != wantCached {
+			t.Errorf("fetch %d: cached = %v, want %v", i+1, cached, wantCached)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "16,1,2,0,4,2,7,1,2,14\n" {
+		t.Errorf("unexpected input %q", data)
+	}
+}
+
+func TestFetchInputError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+	})
+
+	path := filepath.Join(t.TempDir(), "day01.txt")
+
+	_, err := c.FetchInput(2021, 1, path)
+	if err == nil || !strings.Contains(err.Error(), "log in") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err = os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("input file was created after failed download")
+	}
+}
+
+func TestReadSession(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "session")
+	os.WriteFile(file, []byte("fromfile\n"), 0o600)
+
+	t.Setenv(SessionEnv, "")
+	if s, err := ReadSession(file); err != nil || s != "fromfile" {
+		t.Errorf("ReadSession = %q, %v", s, err)
+	}
+
+	t.Setenv(SessionEnv, "fromenv")
+	if s, err := ReadSession(file); err != nil || s != "fromenv" {
+		t.Errorf("ReadSession = %q, %v", s, err)
+	}
+}
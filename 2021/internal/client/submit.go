@@ -0,0 +1,117 @@
+package client
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Verdict string
+
+const (
+	Correct       Verdict = "correct"
+	Wrong         Verdict = "wrong"
+	TooSoon       Verdict = "too soon"
+	AlreadySolved Verdict = "already solved"
+	Unknown       Verdict = "unknown"
+)
+
+type Hint string
+
+const (
+	NoHint  Hint = ""
+	TooHigh Hint = "too high"
+	TooLow  Hint = "too low"
+)
+
+// Response is the website's reaction to a submitted answer.
+type Response struct {
+	Verdict Verdict
+	Hint    Hint
+	Wait    time.Duration // How long to wait before the next attempt
+	Message string
+}
+
+// WrongAnswerWait is assumed if the response to a wrong answer does not say
+// how long to wait.
+const WrongAnswerWait = time.Minute
+
+var (
+	articleRx  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRx      = regexp.MustCompile(`<[^>]*>`)
+	leftRx     = regexp.MustCompile(`have ((?:\d+[hms]\s*)+) left to wait`)
+	pleaseRx   = regexp.MustCompile(`wait (one|\d+) minutes? before trying again`)
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// ParseResponse interprets the HTML page returned after submitting an answer.
+func ParseResponse(page string) Response {
+	msg := page
+	if m := articleRx.FindStringSubmatch(page); m != nil {
+		msg = m[1]
+	}
+
+	msg = html.UnescapeString(tagRx.ReplaceAllString(msg, ""))
+	msg = strings.TrimSpace(whitespace.ReplaceAllString(msg, " "))
+
+	resp := Response{Verdict: Unknown, Message: msg}
+
+	switch {
+	case strings.Contains(msg, "That's the right answer"):
+		resp.Verdict = Correct
+	case strings.Contains(msg, "That's not the right answer"):
+		resp.Verdict = Wrong
+		resp.Wait = WrongAnswerWait
+	case strings.Contains(msg, "You gave an answer too recently"):
+		resp.Verdict = TooSoon
+	case strings.Contains(msg, "You don't seem to be solving the right level"):
+		resp.Verdict = AlreadySolved
+	}
+
+	switch {
+	case strings.Contains(msg, "your answer is too high"):
+		resp.Hint = TooHigh
+	case strings.Contains(msg, "your answer is too low"):
+		resp.Hint = TooLow
+	}
+
+	if m := leftRx.FindStringSubmatch(msg); m != nil {
+		resp.Wait, _ = time.ParseDuration(strings.ReplaceAll(m[1], " ", ""))
+	} else if m := pleaseRx.FindStringSubmatch(msg); m != nil {
+		min := 1
+		if m[1] != "one" {
+			min, _ = strconv.Atoi(m[1])
+		}
+		resp.Wait = time.Duration(min) * time.Minute
+	}
+
+	return resp
+}
+
+// Submit sends the answer to one part of a puzzle.
+func (c *Client) Submit(year, day, part int, answer string) (Response, error) {
+	u := fmt.Sprintf("%s/%d/day/%d/answer", c.BaseURL, year, day)
+
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Response{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	page, err := c.do(req)
+	if err != nil {
+		return Response{}, err
+	}
+
+	return ParseResponse(string(page)), nil
+}
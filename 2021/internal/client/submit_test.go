@@ -0,0 +1,75 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		page string
+		want Response
+	}{
+		{
+			`<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to saving your vacation. <a href="/2021/day/7#part2">[Continue to Part Two]</a></p></article></main>`,
+			Response{Verdict: Correct},
+		},
+		{
+			`<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. [<a href="/2021/day/7">Return to Day 7</a>]</p></article>`,
+			Response{Verdict: Wrong, Hint: TooHigh, Wait: time.Minute},
+		},
+		{
+			`<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article>`,
+			Response{Verdict: Wrong, Hint: TooLow, Wait: 5 * time.Minute},
+		},
+		{
+			`<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data.</p></article>`,
+			Response{Verdict: Wrong, Wait: WrongAnswerWait},
+		},
+		{
+			`<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 20s left to wait. [<a href="/2021/day/7">Return to Day 7</a>]</p></article>`,
+			Response{Verdict: TooSoon, Wait: 80 * time.Second},
+		},
+		{
+			`<article><p>You don't seem to be solving the right level.  Did you already complete it? [<a href="/2021/day/7">Return to Day 7</a>]</p></article>`,
+			Response{Verdict: AlreadySolved},
+		},
+		{
+			`<html>Something else</html>`,
+			Response{Verdict: Unknown},
+		},
+	}
+
+	for _, test := range tests {
+		got := ParseResponse(test.page)
+		got.Message = ""
+
+		if got != test.want {
+			t.Errorf("ParseResponse(%.40q...) = %+v, want %+v", test.page, got, test.want)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2021/day/7/answer" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if r.FormValue("level") != "2" || r.FormValue("answer") != "168" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+
+		w.Write([]byte("<article><p>That's the right answer!</p></article>"))
+	})
+
+	resp, err := c.Submit(2021, 7, 2, "168")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Verdict != Correct {
+		t.Errorf("got verdict %q, want %q", resp.Verdict, Correct)
+	}
+}
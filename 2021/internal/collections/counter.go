@@ -0,0 +1,65 @@
+package collections
+
+import "sort"
+
+// Counter counts how often each value occurs.
+type Counter[T Ordered] map[T]int
+
+// Count is a value together with how often it occurs.
+type Count[T Ordered] struct {
+	Value T
+	N     int
+}
+
+func (c Counter[T]) Add(v T, n int) {
+	c[v] += n
+}
+
+// Total returns the sum of all counts.
+func (c Counter[T]) Total() (total int) {
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// Counts returns all values with their counts, most common first. Values
+// occurring equally often are ordered by value.
+func (c Counter[T]) Counts() []Count[T] {
+	counts := make([]Count[T], 0, len(c))
+	for v, n := range c {
+		counts = append(counts, Count[T]{v, n})
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].N != counts[j].N {
+			return counts[i].N > counts[j].N
+		}
+		return counts[i].Value < counts[j].Value
+	})
+
+	return counts
+}
+
+// MostCommon returns the n most common values, or all if n is negative.
+func (c Counter[T]) MostCommon(n int) []Count[T] {
+	counts := c.Counts()
+	if n >= 0 && n < len(counts) {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// LeastCommon returns the n least common values, or all if n is negative,
+// starting with the least common one. Values occurring equally often are
+// ordered by value.
+func (c Counter[T]) LeastCommon(n int) []Count[T] {
+	counts := c.Counts()
+
+	sort.SliceStable(counts, func(i, j int) bool { return counts[i].N < counts[j].N })
+
+	if n >= 0 && n < len(counts) {
+		counts = counts[:n]
+	}
+	return counts
+}
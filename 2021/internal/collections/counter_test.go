@@ -0,0 +1,36 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	c := make(Counter[byte])
+
+	for _, b := range []byte("NBCCNBBBCBHCB") {
+		c.Add(b, 1)
+	}
+
+	if total := c.Total(); total != 13 {
+		t.Errorf("got total %d, want 13", total)
+	}
+
+	want := []Count[byte]{{'B', 6}, {'C', 4}, {'N', 2}, {'H', 1}}
+	if got := c.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Counts() = %v, want %v", got, want)
+	}
+
+	if got := c.MostCommon(1); !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("MostCommon(1) = %v", got)
+	}
+
+	if got := c.LeastCommon(2); !reflect.DeepEqual(got, []Count[byte]{{'H', 1}, {'N', 2}}) {
+		t.Errorf("LeastCommon(2) = %v", got)
+	}
+
+	c.Add('H', 1)
+	if got := c.LeastCommon(-1); !reflect.DeepEqual(got, []Count[byte]{{'H', 2}, {'N', 2}, {'C', 4}, {'B', 6}}) {
+		t.Errorf("ties are not ordered by value: %v", got)
+	}
+}
@@ -0,0 +1,105 @@
+// Package collections implements sets and counters on top of maps, with
+// helpers to iterate over them in a deterministic order.
+package collections
+
+import "sort"
+
+// Ordered is the set of types that can be compared with <.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Set is an unordered collection of distinct values.
+type Set[T comparable] map[T]struct{}
+
+func NewSet[T comparable](values ...T) Set[T] {
+	s := make(Set[T], len(values))
+	s.Add(values...)
+	return s
+}
+
+func (s Set[T]) Add(values ...T) {
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+}
+
+func (s Set[T]) Remove(values ...T) {
+	for _, v := range values {
+		delete(s, v)
+	}
+}
+
+func (s Set[T]) Contains(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+func (s Set[T]) Clone() Set[T] {
+	c := make(Set[T], len(s))
+	for v := range s {
+		c[v] = struct{}{}
+	}
+	return c
+}
+
+// Union returns a new set with the values that are in s or o.
+func (s Set[T]) Union(o Set[T]) Set[T] {
+	u := s.Clone()
+	for v := range o {
+		u[v] = struct{}{}
+	}
+	return u
+}
+
+// Intersection returns a new set with the values that are in both s and o.
+func (s Set[T]) Intersection(o Set[T]) Set[T] {
+	if len(o) < len(s) {
+		s, o = o, s
+	}
+
+	i := make(Set[T])
+	for v := range s {
+		if o.Contains(v) {
+			i[v] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Difference returns a new set with the values that are in s but not in o.
+func (s Set[T]) Difference(o Set[T]) Set[T] {
+	d := make(Set[T])
+	for v := range s {
+		if !o.Contains(v) {
+			d[v] = struct{}{}
+		}
+	}
+	return d
+}
+
+// Sorted returns the values in s ordered by less.
+func (s Set[T]) Sorted(less func(a, b T) bool) []T {
+	values := make([]T, 0, len(s))
+	for v := range s {
+		values = append(values, v)
+	}
+
+	sort.Slice(values, func(i, j int) bool { return less(values[i], values[j]) })
+
+	return values
+}
+
+// SortedKeys returns the keys of m in ascending order.
+func SortedKeys[K Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	return keys
+}
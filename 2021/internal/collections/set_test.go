@@ -0,0 +1,52 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+)
+
+func sorted(s Set[int]) []int {
+	return s.Sorted(func(a, b int) bool { return a < b })
+}
+
+func TestSet(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"Intersection", a.Intersection(b), []int{3, 4}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"Difference", b.Difference(a), []int{5}},
+	}
+
+	for _, test := range tests {
+		if got := sorted(test.got); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	if got := sorted(a); !reflect.DeepEqual(got, []int{1, 2, 3, 4}) {
+		t.Errorf("set operations changed their operand to %v", got)
+	}
+
+	c := a.Clone()
+	c.Remove(1, 2)
+	c.Add(7)
+
+	if !c.Contains(7) || c.Contains(1) || !a.Contains(1) {
+		t.Errorf("clone %v is not independent of %v", sorted(c), sorted(a))
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	m := map[string]bool{"b": true, "c": false, "a": true}
+
+	if got := SortedKeys(m); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,87 @@
+// Solution for https://adventofcode.com/2021/day/1
+
+package day01
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+func init() {
+	aoc.Register(aoc.Day{Number: 1, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Depths []int
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	depths, err := readDepths(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read input: %w", err)
+	}
+
+	return &Puzzle{Depths: depths}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	var incr int
+
+	for i := 1; i < len(p.Depths); i++ {
+		if p.Depths[i] > p.Depths[i-1] {
+			incr++
+		}
+	}
+
+	return strconv.Itoa(incr), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	var incr int
+
+	for i := 0; i < len(p.Depths)-3; i++ {
+		first := sliceSum(p.Depths[i : i+3])
+		second := sliceSum(p.Depths[i+1 : i+4])
+
+		if second > first {
+			incr++
+		}
+	}
+
+	return strconv.Itoa(incr), nil
+}
+
+func sliceSum(s []int) (sum int) {
+	for _, i := range s {
+		sum += i
+	}
+	return sum
+}
+
+func readDepths(r io.Reader) ([]int, error) {
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	depths := make([]int, 0, len(lines))
+
+	for _, l := range lines {
+		d, err := strconv.Atoi(l.Text)
+		if err != nil {
+			return nil, l.Errorf("invalid depth %q", l.Text)
+		}
+
+		depths = append(depths, d)
+	}
+
+	return depths, nil
+}
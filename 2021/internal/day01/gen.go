@@ -0,0 +1,26 @@
+package day01
+
+import (
+	"bufio"
+	"math/rand"
+	"strconv"
+)
+
+// Generate writes size depth measurements that mostly increase, like the
+// sea floor seen by a descending submarine.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 2000
+	}
+
+	depth := 100 + rnd.Intn(100)
+
+	for i := 0; i < size; i++ {
+		w.WriteString(strconv.Itoa(depth))
+		w.WriteByte('\n')
+
+		if depth += rnd.Intn(40) - 10; depth < 0 {
+			depth = 0
+		}
+	}
+}
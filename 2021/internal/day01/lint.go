@@ -0,0 +1,14 @@
+package day01
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var depthRx = regexp.MustCompile(`^\d+$`)
+
+// Lint checks that each line holds a depth measurement.
+func Lint(input []byte) []lint.Diagnostic {
+	return lint.Pattern(lint.Lines(input), depthRx, "0123456789", "a depth")
+}
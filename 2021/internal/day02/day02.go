@@ -0,0 +1,135 @@
+// Solution for https://adventofcode.com/2021/day/2
+
+package day02
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+type Direction int
+
+const (
+	Forward Direction = iota
+	Down
+	Up
+)
+
+type Command struct {
+	Dir   Direction
+	Delta int
+}
+
+func ParseCommand(line string) (*Command, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid line: %q", line)
+	}
+
+	var c Command
+
+	switch fields[0] {
+	case "forward":
+		c.Dir = Forward
+	case "down":
+		c.Dir = Down
+	case "up":
+		c.Dir = Up
+	default:
+		return nil, fmt.Errorf("unknown command \"%s\"", fields[0])
+	}
+
+	delta, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, err
+	}
+	c.Delta = delta
+
+	return &c, nil
+}
+
+type Submarine struct {
+	Aim   int
+	HPos  int
+	Depth int
+}
+
+func (s *Submarine) SteerDirectly(cmd *Command) {
+	switch cmd.Dir {
+	case Forward:
+		s.HPos += cmd.Delta
+	case Down:
+		s.Depth += cmd.Delta
+	case Up:
+		s.Depth -= cmd.Delta
+	}
+}
+
+func (s *Submarine) SteerWithAim(cmd *Command) {
+	switch cmd.Dir {
+	case Forward:
+		s.HPos += cmd.Delta
+		s.Depth += s.Aim * cmd.Delta
+	case Down:
+		s.Aim += cmd.Delta
+	case Up:
+		s.Aim -= cmd.Delta
+	}
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 2, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Commands []*Command
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	var p Puzzle
+
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range lines {
+		cmd, err := ParseCommand(l.Text)
+		if err != nil {
+			return nil, l.Wrap(err)
+		}
+
+		p.Commands = append(p.Commands, cmd)
+	}
+
+	return &p, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	var sub Submarine
+
+	for _, cmd := range p.Commands {
+		sub.SteerDirectly(cmd)
+	}
+
+	return strconv.Itoa(sub.HPos * sub.Depth), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	var sub Submarine
+
+	for _, cmd := range p.Commands {
+		sub.SteerWithAim(cmd)
+	}
+
+	return strconv.Itoa(sub.HPos * sub.Depth), nil
+}
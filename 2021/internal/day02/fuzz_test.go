@@ -0,0 +1,34 @@
+package day02
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func FuzzParseCommand(f *testing.F) {
+	files, _ := filepath.Glob("../days/testdata/day02/*.txt")
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			f.Add(line)
+		}
+	}
+	f.Add("up -3")
+	f.Add("sideways 1")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		c, err := ParseCommand(s)
+		if (c == nil) == (err == nil) {
+			t.Fatalf("ParseCommand(%q) = %v, %v", s, c, err)
+		}
+
+		if c != nil && c.Dir != Forward && c.Dir != Down && c.Dir != Up {
+			t.Errorf("ParseCommand(%q) returned direction %v", s, c.Dir)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package day02
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generate writes size commands that never take the submarine above the
+// surface.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 1000
+	}
+
+	var depth int
+
+	for i := 0; i < size; i++ {
+		units := 1 + rnd.Intn(9)
+
+		switch n := rnd.Intn(3); {
+		case n == 0:
+			fmt.Fprintf(w, "forward %d\n", units)
+		case n == 1 || depth < units:
+			fmt.Fprintf(w, "down %d\n", units)
+			depth += units
+		default:
+			fmt.Fprintf(w, "up %d\n", units)
+			depth -= units
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package day02
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var commandRx = regexp.MustCompile(`^(forward|down|up) \d+$`)
+
+// Lint checks that each line holds a command.
+func Lint(input []byte) []lint.Diagnostic {
+	return lint.Pattern(lint.Lines(input), commandRx, "adfnoprwu 0123456789", "\"forward|down|up <units>\"")
+}
@@ -0,0 +1,151 @@
+// Solution for https://adventofcode.com/2021/day/3
+
+package day03
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+// FieldWidth is the number of bits in the official inputs.
+const FieldWidth = 12
+
+func init() {
+	aoc.Register(aoc.Day{Number: 3, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Numbers []uint
+	Width   int // Bits of each number, taken from the input by Parse
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	var p Puzzle
+
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range lines {
+		if p.Width == 0 {
+			p.Width = len(l.Text)
+		} else if len(l.Text) != p.Width {
+			return nil, l.Errorf("expected %d bits, got %q", p.Width, l.Text)
+		}
+
+		num, err := strconv.ParseUint(l.Text, 2, strconv.IntSize-1)
+		if err != nil {
+			return nil, l.Errorf("invalid binary number %q", l.Text)
+		}
+
+		p.Numbers = append(p.Numbers, uint(num))
+	}
+
+	return &p, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Params(fs *flag.FlagSet) {
+	fs.IntVar(&p.Width, "width", p.Width, "look at the lowest `n` bits of each number only, or pad them with zeroes")
+}
+
+func (p *Puzzle) checkWidth() error {
+	if p.Width < 1 || p.Width >= strconv.IntSize {
+		return fmt.Errorf("invalid width %d", p.Width)
+	}
+	return nil
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	if err := p.checkWidth(); err != nil {
+		return "", err
+	}
+
+	var gamma uint
+
+	for i := 0; i < p.Width; i++ {
+		gamma <<= 1
+		mc, _ := countBits(p.Numbers, p.Width, i)
+		gamma |= uint(mc)
+	}
+
+	epsilon := ^gamma & ((1 << p.Width) - 1)
+
+	return strconv.FormatUint(uint64(gamma*epsilon), 10), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	if err := p.checkWidth(); err != nil {
+		return "", err
+	}
+
+	oxygen := p.Numbers
+
+	for pos := 0; pos < p.Width && len(oxygen) > 1; pos++ {
+		mc, _ := countBits(oxygen, p.Width, pos)
+		oxygen = filter(oxygen, p.Width, mc, pos)
+	}
+
+	if len(oxygen) != 1 {
+		return "", fmt.Errorf("%d oxygen generator ratings remaining", len(oxygen))
+	}
+
+	scrubber := p.Numbers
+
+	for pos := 0; pos < p.Width && len(scrubber) > 1; pos++ {
+		_, lc := countBits(scrubber, p.Width, pos)
+		scrubber = filter(scrubber, p.Width, lc, pos)
+	}
+
+	if len(scrubber) != 1 {
+		return "", fmt.Errorf("%d CO2 scrubber ratings remaining", len(scrubber))
+	}
+
+	return strconv.FormatUint(uint64(oxygen[0]*scrubber[0]), 10), nil
+}
+
+func countBits(numbers []uint, width, pos int) (mostCommon, leastCommon uint8) {
+	var ones, zeroes int
+
+	for _, n := range numbers {
+		if (n>>(width-1-pos))&1 == 0 {
+			zeroes++
+		} else {
+			ones++
+		}
+	}
+
+	switch {
+	case zeroes == 0:
+		// All numbers agree, like on the padding of a wider width
+		mostCommon, leastCommon = 1, 1
+	case ones == 0:
+	case ones >= zeroes:
+		mostCommon = 1
+	default:
+		leastCommon = 1
+	}
+
+	return mostCommon, leastCommon
+}
+
+func filter(numbers []uint, width int, bit uint8, pos int) []uint {
+	var filtered []uint
+
+	for _, n := range numbers {
+		if (n>>(width-1-pos))&1 == uint(bit) {
+			filtered = append(filtered, n)
+		}
+	}
+
+	return filtered
+}
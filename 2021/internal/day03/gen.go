@@ -0,0 +1,39 @@
+package day03
+
+import (
+	"bufio"
+	"math/bits"
+	"math/rand"
+	"strconv"
+)
+
+// Generate writes size distinct binary numbers, which the ratings of part 2
+// rely on. The numbers have FieldWidth bits unless more are needed to keep them
+// distinct.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 1000
+	}
+
+	width := bits.Len(uint(2*size - 1))
+	if width < FieldWidth {
+		width = FieldWidth
+	}
+
+	seen := make(map[uint64]bool)
+
+	for len(seen) < size {
+		n := rnd.Uint64() & (1<<width - 1)
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+
+		s := strconv.FormatUint(n, 2)
+		for i := len(s); i < width; i++ {
+			w.WriteByte('0')
+		}
+		w.WriteString(s)
+		w.WriteByte('\n')
+	}
+}
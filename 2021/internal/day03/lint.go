@@ -0,0 +1,23 @@
+package day03
+
+import (
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+// Lint checks that the lines are binary numbers of equal width that fit
+// into an int.
+func Lint(input []byte) []lint.Diagnostic {
+	lines := lint.Lines(input)
+
+	diags := lint.Grid(lines, "01", 0, 0)
+
+	if len(lines) > 0 {
+		if l := lines[0]; len(l.Text) > strconv.IntSize-1 {
+			diags = append(diags, lint.Errorf(l.Num, strconv.IntSize, "numbers have %d bits, at most %d are supported", len(l.Text), strconv.IntSize-1))
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,320 @@
+// Solution for https://adventofcode.com/2021/day/4
+
+package day04
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+const (
+	BoardSize = 5
+)
+
+type Field struct {
+	Number uint8
+	Marked bool
+}
+
+type Board struct {
+	Fields []Field
+}
+
+func NewBoard(numbers []uint8) (*Board, error) {
+	if len(numbers) != BoardSize*BoardSize {
+		return nil, fmt.Errorf("board has %d numbers instead of %d", len(numbers), BoardSize*BoardSize)
+	}
+
+	b := &Board{Fields: make([]Field, len(numbers))}
+
+	for i := 0; i < len(numbers); i++ {
+		b.Fields[i].Number = numbers[i]
+	}
+
+	return b, nil
+}
+
+func (b *Board) Mark(number uint8) {
+	for i := 0; i < len(b.Fields); i++ {
+		if b.Fields[i].Number == number {
+			b.Fields[i].Marked = true
+			break
+		}
+	}
+}
+
+func (b *Board) Reset() {
+	for i := 0; i < len(b.Fields); i++ {
+		b.Fields[i].Marked = false
+	}
+}
+
+func (b *Board) HasWon() bool {
+	for row := 0; row < BoardSize; row++ {
+		hasUnmarked := false
+		for col := 0; col < BoardSize && !hasUnmarked; col++ {
+			hasUnmarked = !b.Fields[row*BoardSize+col].Marked
+		}
+
+		if !hasUnmarked {
+			return true
+		}
+	}
+
+	for col := 0; col < BoardSize; col++ {
+		hasUnmarked := false
+		for row := 0; row < BoardSize && !hasUnmarked; row++ {
+			hasUnmarked = !b.Fields[row*BoardSize+col].Marked
+		}
+
+		if !hasUnmarked {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *Board) Score() (score int) {
+	for _, f := range b.Fields {
+		if !f.Marked {
+			score += int(f.Number)
+		}
+	}
+
+	return score
+}
+
+func ParseInput(r io.Reader) (randnums []uint8, boards []*Board, err error) {
+	paras, err := parse.Paragraphs(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(paras) == 0 {
+		return nil, nil, fmt.Errorf("empty input")
+	}
+
+	randnums, err = readRandomNumbers(paras[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, para := range paras[1:] {
+		board, err := readBoard(para)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		boards = append(boards, board)
+	}
+
+	if len(boards) == 0 {
+		return nil, nil, fmt.Errorf("empty list of boards")
+	}
+
+	return randnums, boards, nil
+}
+
+func readRandomNumbers(para []parse.Line) ([]uint8, error) {
+	if len(para) > 1 {
+		return nil, para[1].Errorf("expected a blank line after the random numbers")
+	}
+
+	list, err := para[0].IntList()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, para[0].Errorf("empty list of random guesses")
+	}
+
+	randnums := make([]uint8, len(list))
+
+	for i, n := range list {
+		if n < 0 || n > math.MaxUint8 {
+			return nil, para[0].Errorf("random number %d out of range", n)
+		}
+		randnums[i] = uint8(n)
+	}
+
+	return randnums, nil
+}
+
+func readBoard(para []parse.Line) (*Board, error) {
+	if len(para) == 0 {
+		return nil, fmt.Errorf("empty board")
+	}
+
+	if len(para) != BoardSize {
+		return nil, para[0].Errorf("board has %d rows instead of %d", len(para), BoardSize)
+	}
+
+	var boardnums []uint8
+
+	for _, l := range para {
+		fields := strings.Fields(l.Text)
+
+		if len(fields) != BoardSize {
+			return nil, l.Errorf("board row has %d numbers instead of %d", len(fields), BoardSize)
+		}
+
+		for _, field := range fields {
+			num, err := strconv.ParseUint(field, 10, 8)
+			if err != nil {
+				return nil, l.Errorf("invalid board number %q", field)
+			}
+
+			boardnums = append(boardnums, uint8(num))
+		}
+	}
+
+	return NewBoard(boardnums)
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 4, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Numbers []uint8
+	Boards  []*Board
+
+	sink frame.Sink
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	randnums, boards, err := ParseInput(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Puzzle{Numbers: randnums, Boards: boards}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+// boardsPerRow is how many boards frames show next to each other.
+const boardsPerRow = 10
+
+func (p *Puzzle) Record(sink frame.Sink) {
+	p.sink = sink
+}
+
+// emit sends a frame of all boards to the sink, if there is one. Fields are
+// shown as '.' if unmarked, '#' if marked and '@' if marked on a board that
+// has won.
+func (p *Puzzle) emit(step int, label string) {
+	if p.sink == nil {
+		return
+	}
+
+	cols := len(p.Boards)
+	if cols > boardsPerRow {
+		cols = boardsPerRow
+	}
+	rows := (len(p.Boards) + cols - 1) / cols
+
+	f := &frame.Frame{
+		Step:  step,
+		Label: label,
+		Grid:  grid.New[uint8](cols*(BoardSize+1)-1, rows*(BoardSize+1)-1),
+		Max:   3,
+		Chars: " .#@",
+	}
+
+	for i, b := range p.Boards {
+		marked := uint8(2)
+		if b.HasWon() {
+			marked = 3
+		}
+
+		origin := grid.Point{X: i % cols * (BoardSize + 1), Y: i / cols * (BoardSize + 1)}
+
+		for j, field := range b.Fields {
+			v := uint8(1)
+			if field.Marked {
+				v = marked
+			}
+			f.Grid.Set(origin.Add(grid.Point{X: j % BoardSize, Y: j / BoardSize}), v)
+		}
+	}
+
+	p.sink.Emit(f)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	for _, b := range p.Boards {
+		b.Reset()
+	}
+
+	p.emit(0, "")
+
+	for step, rand := range p.Numbers {
+		for _, board := range p.Boards {
+			board.Mark(rand)
+
+			if board.HasWon() {
+				p.emit(step+1, fmt.Sprintf("draw %d, bingo", rand))
+				return strconv.Itoa(board.Score() * int(rand)), nil
+			}
+		}
+
+		p.emit(step+1, fmt.Sprintf("draw %d", rand))
+	}
+
+	return "", fmt.Errorf("no winner")
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	for _, b := range p.Boards {
+		b.Reset()
+	}
+
+	var (
+		lastwinner *Board
+		lastbingo  uint8
+	)
+
+	boards := make([]*Board, len(p.Boards))
+	copy(boards, p.Boards)
+
+	p.emit(0, "")
+
+	for step, rand := range p.Numbers {
+		if len(boards) == 0 {
+			break
+		}
+
+		for i := 0; i < len(boards); i++ {
+			boards[i].Mark(rand)
+
+			if boards[i].HasWon() {
+				lastwinner = boards[i]
+				lastbingo = rand
+				boards[i] = boards[len(boards)-1]
+				boards = boards[:len(boards)-1]
+				i--
+			}
+		}
+
+		p.emit(step+1, fmt.Sprintf("draw %d, %d boards left", rand, len(boards)))
+	}
+
+	if lastwinner == nil {
+		return "", fmt.Errorf("no winner")
+	}
+
+	return strconv.Itoa(lastwinner.Score() * int(lastbingo)), nil
+}
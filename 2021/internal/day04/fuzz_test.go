@@ -0,0 +1,54 @@
+package day04
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+func FuzzReadBoard(f *testing.F) {
+	files, _ := filepath.Glob("../days/testdata/day04/*.txt")
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		paras, err := parse.Paragraphs(strings.NewReader(string(data)))
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		for _, para := range paras[1:] {
+			var sb strings.Builder
+			for _, l := range para {
+				sb.WriteString(l.Text + "\n")
+			}
+			f.Add(sb.String())
+		}
+	}
+	f.Add("1 2 3 4 5\n")
+	f.Add("1 2 3 4 5\n6 7 8 9 10\n11 12 13 14 15\n16 17 18 19 20\n21 22 23 24 256\n")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var para []parse.Line
+		for i, text := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+			para = append(para, parse.Line{Num: i + 1, Text: text})
+		}
+		if s == "" {
+			para = nil
+		}
+
+		b, err := readBoard(para)
+		if (b == nil) == (err == nil) {
+			t.Fatalf("readBoard(%q) = %v, %v", s, b, err)
+		}
+
+		if b != nil && len(b.Fields) != BoardSize*BoardSize {
+			t.Errorf("readBoard(%q) returned %d fields", s, len(b.Fields))
+		}
+	})
+}
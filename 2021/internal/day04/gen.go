@@ -0,0 +1,42 @@
+package day04
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Numbers is how many different numbers there are in the game.
+const Numbers = 100
+
+// Generate writes all numbers in random order and size boards, each with
+// distinct numbers, so that every board wins eventually.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 100
+	}
+
+	draw := make([]string, Numbers)
+	for i, n := range rnd.Perm(Numbers) {
+		draw[i] = strconv.Itoa(n)
+	}
+	w.WriteString(strings.Join(draw, ","))
+	w.WriteByte('\n')
+
+	for i := 0; i < size; i++ {
+		w.WriteByte('\n')
+
+		nums := rnd.Perm(Numbers)[:BoardSize*BoardSize]
+		for j, n := range nums {
+			if j%BoardSize != 0 {
+				w.WriteByte(' ')
+			}
+			fmt.Fprintf(w, "%2d", n)
+			if j%BoardSize == BoardSize-1 {
+				w.WriteByte('\n')
+			}
+		}
+	}
+}
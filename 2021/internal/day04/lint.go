@@ -0,0 +1,33 @@
+package day04
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var (
+	drawRx = regexp.MustCompile(`^\d+(,\d+)*$`)
+	rowRx  = regexp.MustCompile(`^ *\d+( +\d+){4}$`)
+)
+
+// Lint checks that the drawn numbers are followed by 5x5 boards, separated
+// by blank lines.
+func Lint(input []byte) []lint.Diagnostic {
+	paras := lint.Paragraphs(input)
+	if len(paras) < 2 {
+		return []lint.Diagnostic{{Msg: "expected drawn numbers and at least one board"}}
+	}
+
+	diags := lint.Count(paras[0], 1, 1, "lines of drawn numbers")
+	diags = append(diags, lint.Pattern(paras[0], drawRx, "0123456789,", "a comma-separated list of numbers")...)
+
+	for _, para := range paras[1:] {
+		if len(para) != BoardSize {
+			diags = append(diags, lint.Errorf(para[0].Num, 0, "board has %d rows instead of %d", len(para), BoardSize))
+		}
+		diags = append(diags, lint.Pattern(para, rowRx, "0123456789 ", "a row of 5 numbers")...)
+	}
+
+	return diags
+}
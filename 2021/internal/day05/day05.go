@@ -0,0 +1,180 @@
+// Solution for https://adventofcode.com/2021/day/5
+
+package day05
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+	"codeberg.org/mhofmann/adventofcode/internal/geom"
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+type Point = geom.Point[int]
+
+type Line struct {
+	P1, P2 Point
+}
+
+func (l *Line) IsAxisAligned() bool {
+	return l.P1.X == l.P2.X || l.P1.Y == l.P2.Y
+}
+
+func (l *Line) IsDiagonal() bool {
+	return 2*l.P1.Chebyshev(l.P2) == l.P1.Manhattan(l.P2)
+}
+
+// Draw increments the cells of g covered by the line, where the top left cell
+// of g is at origin.
+func (l *Line) Draw(g *grid.Grid[uint8], origin Point) {
+	step := l.P2.Sub(l.P1).Sign()
+
+	p := l.P1
+	for p != l.P2 {
+		*g.Ref(p.Sub(origin))++
+		p = p.Add(step)
+	}
+
+	*g.Ref(p.Sub(origin))++
+}
+
+var lineRx = regexp.MustCompile(`(-?\d+),(-?\d+)\s*->\s*(-?\d+),(-?\d+)`)
+
+func ParseLine(s string) (*Line, error) {
+	matches := lineRx.FindStringSubmatch(s)
+	if len(matches) != 5 {
+		return nil, fmt.Errorf("invalid formatted line \"%s\"", s)
+	}
+
+	var coords [4]int
+
+	for i := 1; i < len(matches); i++ {
+		c, err := strconv.Atoi(matches[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid formatted line \"%s\" (%w)", s, err)
+		}
+
+		coords[i-1] = c
+	}
+
+	l := &Line{
+		P1: geom.Pt(coords[0], coords[1]),
+		P2: geom.Pt(coords[2], coords[3]),
+	}
+
+	if !l.IsAxisAligned() && !l.IsDiagonal() {
+		return nil, fmt.Errorf("line \"%s\" is neither axis-aligned nor diagonal", s)
+	}
+
+	return l, nil
+}
+
+func ParseLines(r io.Reader) (lines []*Line, err error) {
+	input, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, in := range input {
+		l, err := ParseLine(in.Text)
+		if err != nil {
+			return nil, in.Wrap(err)
+		}
+
+		lines = append(lines, l)
+	}
+
+	return lines, nil
+}
+
+func countOverlaps(g *grid.Grid[uint8]) int {
+	return g.Count(func(n uint8) bool { return n > 1 })
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 5, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Lines  []*Line
+	Bounds geom.Box[int]
+
+	sink frame.Sink
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	lines, err := ParseLines(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse input file: %w", err)
+	}
+
+	p := &Puzzle{Lines: lines, Bounds: geom.BoundingBox[int]()}
+
+	for _, l := range lines {
+		p.Bounds = p.Bounds.Extend(l.P1).Extend(l.P2)
+	}
+
+	return p, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Record(sink frame.Sink) {
+	p.sink = sink
+}
+
+// emit sends the number of vents over each cell to the sink if there is one.
+func (p *Puzzle) emit(g *grid.Grid[uint8], label string) {
+	if p.sink == nil {
+		return
+	}
+
+	var max uint8
+	for _, n := range g.Cells {
+		if n > max {
+			max = n
+		}
+	}
+
+	p.sink.Emit(&frame.Frame{
+		Label:   label,
+		Grid:    g,
+		Origin:  p.Bounds.Min,
+		Max:     max,
+		Chars:   ".123456789",
+		Palette: frame.Palettes["heat"],
+	})
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	g := grid.New[uint8](p.Bounds.Width(), p.Bounds.Height())
+
+	for _, l := range p.Lines {
+		if l.IsAxisAligned() {
+			l.Draw(g, p.Bounds.Min)
+		}
+	}
+
+	p.emit(g, "axis-aligned vents")
+
+	return strconv.Itoa(countOverlaps(g)), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	g := grid.New[uint8](p.Bounds.Width(), p.Bounds.Height())
+
+	for _, l := range p.Lines {
+		l.Draw(g, p.Bounds.Min)
+	}
+
+	p.emit(g, "all vents")
+
+	return strconv.Itoa(countOverlaps(g)), nil
+}
@@ -0,0 +1,35 @@
+package day05
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func FuzzParseLine(f *testing.F) {
+	files, _ := filepath.Glob("../days/testdata/day05/*.txt")
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			f.Add(line)
+		}
+	}
+	f.Add("-1,-1 -> 3,3")
+	f.Add("0,0 -> 1,3")
+	f.Add("99999999999999999999,0 -> 0,0")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		l, err := ParseLine(s)
+		if (l == nil) == (err == nil) {
+			t.Fatalf("ParseLine(%q) = %v, %v", s, l, err)
+		}
+
+		if l != nil && !l.IsAxisAligned() && !l.IsDiagonal() {
+			t.Errorf("ParseLine(%q) accepted line %v", s, *l)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package day05
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Extent is the width and height of the ocean floor in the official inputs.
+const Extent = 1000
+
+// Generate writes size horizontal, vertical and diagonal lines.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 500
+	}
+
+	for i := 0; i < size; i++ {
+		x1, y1 := rnd.Intn(Extent), rnd.Intn(Extent)
+		x2, y2 := x1, y1
+
+		switch rnd.Intn(3) {
+		case 0:
+			x2 = rnd.Intn(Extent)
+		case 1:
+			y2 = rnd.Intn(Extent)
+		default:
+			// Stay inside the floor in the direction of the far corner
+			d := rnd.Intn(Extent / 2)
+			dx, dy := 1, 1
+			if x1 >= Extent/2 {
+				dx = -1
+			}
+			if y1 >= Extent/2 {
+				dy = -1
+			}
+			x2, y2 = x1+dx*d, y1+dy*d
+		}
+
+		fmt.Fprintf(w, "%d,%d -> %d,%d\n", x1, y1, x2, y2)
+	}
+}
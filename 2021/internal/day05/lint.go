@@ -0,0 +1,28 @@
+package day05
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var lintRx = regexp.MustCompile(`^\d+,\d+ -> \d+,\d+$`)
+
+// Lint checks that each line describes a horizontal, vertical or diagonal
+// line of vents.
+func Lint(input []byte) []lint.Diagnostic {
+	lines := lint.Lines(input)
+
+	diags := lint.Pattern(lines, lintRx, "0123456789,-> ", "\"x1,y1 -> x2,y2\"")
+	if len(diags) > 0 {
+		return diags
+	}
+
+	for _, l := range lines {
+		if _, err := ParseLine(l.Text); err != nil {
+			diags = append(diags, lint.Errorf(l.Num, 0, "%v", err))
+		}
+	}
+
+	return diags
+}
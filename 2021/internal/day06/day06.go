@@ -0,0 +1,103 @@
+// Solution for https://adventofcode.com/2021/day/6
+
+package day06
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+const (
+	P1SimulationDays = 80
+	P2SimulationDays = 256
+)
+
+func ReadInitialState(r io.Reader) (state []int, err error) {
+	state, err = parse.ReadIntList(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range state {
+		if n < 0 || n > 8 {
+			return nil, fmt.Errorf("invalid timer value %d", n)
+		}
+	}
+
+	return state, nil
+}
+
+func sliceSum(slice []int) (sum int) {
+	for _, n := range slice {
+		sum += n
+	}
+	return sum
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 6, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	State []int
+	Days  [2]int // Simulated in each part, the official numbers unless changed
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	state, err := ReadInitialState(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Puzzle{State: state, Days: [2]int{P1SimulationDays, P2SimulationDays}}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Params(fs *flag.FlagSet) {
+	fs.Func("days", "simulate `n` days in both parts instead of 80 and 256", func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid number of days %q", s)
+		}
+
+		p.Days = [2]int{n, n}
+
+		return nil
+	})
+}
+
+func (p *Puzzle) Simulate(days int) int {
+	fishperage := make([]int, 9)
+
+	for _, fish := range p.State {
+		fishperage[fish]++
+	}
+
+	for i := 0; i < days; i++ {
+		spawn := fishperage[0]
+		for j := 0; j < len(fishperage)-1; j++ {
+			fishperage[j] = fishperage[j+1]
+		}
+
+		fishperage[8] = spawn
+		fishperage[6] += spawn
+	}
+
+	return sliceSum(fishperage)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	return strconv.Itoa(p.Simulate(p.Days[0])), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	return strconv.Itoa(p.Simulate(p.Days[1])), nil
+}
@@ -0,0 +1,21 @@
+package day06
+
+import (
+	"bufio"
+	"math/rand"
+)
+
+// Generate writes the timers of size lanternfish.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 300
+	}
+
+	for i := 0; i < size; i++ {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteByte(byte('1' + rnd.Intn(5)))
+	}
+	w.WriteByte('\n')
+}
@@ -0,0 +1,19 @@
+package day06
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var timersRx = regexp.MustCompile(`^[0-8](,[0-8])*$`)
+
+// Lint checks that the input is a single line of timers from 0 to 8.
+func Lint(input []byte) []lint.Diagnostic {
+	lines := lint.Lines(input)
+
+	diags := lint.Count(lines, 1, 1, "lines")
+	diags = append(diags, lint.Pattern(lines, timersRx, "012345678,", "a comma-separated list of timers from 0 to 8")...)
+
+	return diags
+}
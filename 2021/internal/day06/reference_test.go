@@ -0,0 +1,37 @@
+package day06
+
+import (
+	"testing"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc/aoctest"
+)
+
+// referenceSimulate keeps track of every single fish.
+func referenceSimulate(state []int, days int) int {
+	fish := append([]int(nil), state...)
+
+	for day := 0; day < days; day++ {
+		for i := range fish {
+			if fish[i] == 0 {
+				fish[i] = 6
+				fish = append(fish, 8)
+			} else {
+				fish[i]--
+			}
+		}
+	}
+
+	return len(fish)
+}
+
+func TestSimulateReference(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		p := aoctest.Generate[*Puzzle](t, 6, seed, 10)
+
+		for days := 0; days <= P1SimulationDays; days += 5 {
+			if got, want := p.Simulate(days), referenceSimulate(p.State, days); got != want {
+				t.Errorf("seed %d, %d days: got %d fish, want %d", seed, days, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Solution for https://adventofcode.com/2021/day/7
+
+package day07
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+func ReadPositions(r io.Reader) ([]int, error) {
+	return parse.ReadIntList(r)
+}
+
+func abs(n int) int {
+	if n >= 0 {
+		return n
+	}
+	return -n
+}
+
+func sliceMode(slice []int) int {
+	if len(slice)%2 == 0 {
+		return (slice[len(slice)/2] + slice[len(slice)/2-1]) / 2
+	}
+	return slice[len(slice)/2]
+}
+
+func sliceMean(slice []int) int {
+	sum := 0
+	for _, n := range slice {
+		sum += n
+	}
+
+	return sum / len(slice)
+}
+
+func fuelCost(x1, x2 int) int {
+	n := abs(x2 - x1)
+	return n * (n + 1) / 2
+}
+
+func totalFuelCost(positions []int, target int) (fuel int) {
+	for _, n := range positions {
+		fuel += fuelCost(n, target)
+	}
+	return fuel
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 7, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Positions []int
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	pos, err := ReadPositions(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pos) == 0 {
+		return nil, errors.New("no crab positions")
+	}
+
+	sort.Ints(pos)
+
+	return &Puzzle{Positions: pos}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	mode := sliceMode(p.Positions)
+
+	var fuel int
+	for _, n := range p.Positions {
+		fuel += abs(n - mode)
+	}
+
+	return strconv.Itoa(fuel), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	// The truncated mean is close to the cheapest position, but not always
+	// right on it. The total cost is convex, so walk downhill from there.
+	best := sliceMean(p.Positions)
+	fuel := totalFuelCost(p.Positions, best)
+
+	for _, dir := range []int{-1, 1} {
+		for {
+			f := totalFuelCost(p.Positions, best+dir)
+			if f >= fuel {
+				break
+			}
+
+			best += dir
+			fuel = f
+		}
+	}
+
+	return strconv.Itoa(fuel), nil
+}
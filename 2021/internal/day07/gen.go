@@ -0,0 +1,28 @@
+package day07
+
+import (
+	"bufio"
+	"math/rand"
+	"strconv"
+)
+
+// Generate writes the positions of size crabs, spread over twice as many
+// positions with a bias towards the low end like the official inputs.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 1000
+	}
+
+	for i := 0; i < size; i++ {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+
+		pos := rnd.Intn(2 * size)
+		if rnd.Intn(2) == 0 {
+			pos /= 4
+		}
+		w.WriteString(strconv.Itoa(pos))
+	}
+	w.WriteByte('\n')
+}
@@ -0,0 +1,19 @@
+package day07
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var positionsRx = regexp.MustCompile(`^\d+(,\d+)*$`)
+
+// Lint checks that the input is a single line of crab positions.
+func Lint(input []byte) []lint.Diagnostic {
+	lines := lint.Lines(input)
+
+	diags := lint.Count(lines, 1, 1, "lines")
+	diags = append(diags, lint.Pattern(lines, positionsRx, "0123456789,", "a comma-separated list of positions")...)
+
+	return diags
+}
@@ -0,0 +1,57 @@
+package day07
+
+import (
+	"strconv"
+	"testing"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc/aoctest"
+)
+
+// referenceFuel tries every position between the outermost crabs and moves
+// each crab one step at a time, where step i costs cost(i).
+func referenceFuel(positions []int, cost func(step int) int) int {
+	lo, hi := positions[0], positions[0]
+	for _, pos := range positions {
+		if pos < lo {
+			lo = pos
+		}
+		if pos > hi {
+			hi = pos
+		}
+	}
+
+	best := -1
+
+	for target := lo; target <= hi; target++ {
+		var fuel int
+
+		for _, pos := range positions {
+			for step := 1; step <= abs(target-pos); step++ {
+				fuel += cost(step)
+			}
+		}
+
+		if best < 0 || fuel < best {
+			best = fuel
+		}
+	}
+
+	return best
+}
+
+func TestFuelReference(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		p := aoctest.Generate[*Puzzle](t, 7, seed, 1+int(seed))
+
+		want1 := referenceFuel(p.Positions, func(int) int { return 1 })
+		want2 := referenceFuel(p.Positions, func(step int) int { return step })
+
+		if got, _ := p.Part1(); got != strconv.Itoa(want1) {
+			t.Errorf("seed %d: part 1 got %s, want %d", seed, got, want1)
+		}
+
+		if got, _ := p.Part2(); got != strconv.Itoa(want2) {
+			t.Errorf("seed %d: part 2 got %s, want %d", seed, got, want2)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// Solution for https://adventofcode.com/2021/day/8
+
+package day08
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+type Pattern uint8
+
+const (
+	SegA Pattern = 1 << iota
+	SegB
+	SegC
+	SegD
+	SegE
+	SegF
+	SegG
+)
+
+func PatternFromString(s string) (p Pattern, err error) {
+	for _, r := range s {
+		switch r {
+		case 'a':
+			p |= SegA
+		case 'b':
+			p |= SegB
+		case 'c':
+			p |= SegC
+		case 'd':
+			p |= SegD
+		case 'e':
+			p |= SegE
+		case 'f':
+			p |= SegF
+		case 'g':
+			p |= SegG
+		default:
+			return 0, fmt.Errorf("invalid segment pattern %q", s)
+		}
+	}
+
+	return p, nil
+}
+
+func PatternsFromStrings(list []string) ([]Pattern, error) {
+	patterns := make([]Pattern, len(list))
+
+	for i, s := range list {
+		p, err := PatternFromString(s)
+		if err != nil {
+			return nil, err
+		}
+		patterns[i] = p
+	}
+
+	return patterns, nil
+}
+
+func NewPatternDecoder(patterns []Pattern) (map[Pattern]int, error) {
+	if len(patterns) != 10 {
+		return nil, fmt.Errorf("expected 10 patterns, got %d", len(patterns))
+	}
+
+	encoder := make([]Pattern, 10)
+	decoder := make(map[Pattern]int)
+
+	var l5, l6 []Pattern
+
+	// Find-fixed lengh patterns
+	for _, p := range patterns {
+		switch bits.OnesCount8(uint8(p)) {
+		case 2:
+			encoder[1] = p
+			decoder[p] = 1
+		case 3:
+			encoder[7] = p
+			decoder[p] = 7
+		case 4:
+			encoder[4] = p
+			decoder[p] = 4
+		case 5:
+			l5 = append(l5, p)
+		case 6:
+			l6 = append(l6, p)
+		case 7:
+			encoder[8] = p
+			decoder[p] = 8
+		default:
+			return nil, fmt.Errorf("pattern %07b has %d segments", p, bits.OnesCount8(uint8(p)))
+		}
+	}
+
+	if err := CheckSliceLength(l5, 3); err != nil {
+		return nil, err
+	}
+	if err := CheckSliceLength(l6, 3); err != nil {
+		return nil, err
+	}
+
+	// 0 and 9 have both bits from 1 set, 6 only one of them
+	for i, p := range l6 {
+		if p&encoder[1] != encoder[1] {
+			encoder[6] = p
+			decoder[p] = 6
+			l6[i] = l6[len(l6)-1]
+			l6 = l6[:len(l6)-1]
+			break
+		}
+	}
+
+	if err := CheckSliceLength(l6, 2); err != nil {
+		return nil, err
+	}
+
+	// 9 has all bits from 4 set, 0 doesn't
+	if l6[0]&encoder[4] == encoder[4] {
+		encoder[9] = l6[0]
+		decoder[l6[0]] = 9
+		encoder[0] = l6[1]
+		decoder[l6[1]] = 0
+	} else {
+		encoder[9] = l6[1]
+		decoder[l6[1]] = 9
+		encoder[0] = l6[0]
+		decoder[l6[0]] = 0
+	}
+
+	// 3 has all bits from 1 set, 2 and 5 do not
+	for i, p := range l5 {
+		if p&encoder[1] == encoder[1] {
+			encoder[3] = p
+			decoder[p] = 3
+			l5[i] = l5[len(l5)-1]
+			l5 = l5[:len(l5)-1]
+			break
+		}
+	}
+
+	if err := CheckSliceLength(l5, 2); err != nil {
+		return nil, err
+	}
+
+	// 6 has all bits of 5 set, but not of 2
+	if l5[0]&encoder[6] == l5[0] {
+		encoder[5] = l5[0]
+		decoder[l5[0]] = 5
+		encoder[2] = l5[1]
+		decoder[l5[1]] = 2
+	} else {
+		encoder[5] = l5[1]
+		decoder[l5[1]] = 5
+		encoder[2] = l5[0]
+		decoder[l5[0]] = 2
+	}
+
+	for i := 0; i < 10; i++ {
+		if decoder[encoder[i]] != i {
+			return nil, fmt.Errorf("no valid decoding for %d", i)
+		}
+	}
+
+	return decoder, nil
+}
+
+func CheckSliceLength(slice []Pattern, n int) error {
+	if len(slice) != n {
+		return fmt.Errorf("expected %d patterns of that length, got %d", n, len(slice))
+	}
+	return nil
+}
+
+func EvaluateEntry(entry string) (uniques, value int, err error) {
+	parts := strings.FieldsFunc(entry, func(r rune) bool { return r == '|' })
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid entry with %d parts", len(parts))
+	}
+
+	patterns, err := PatternsFromStrings(strings.Fields(parts[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	outputs, err := PatternsFromStrings(strings.Fields(parts[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(outputs) == 0 {
+		return 0, 0, fmt.Errorf("no output values in entry %q", entry)
+	}
+
+	decoder, err := NewPatternDecoder(patterns)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, val := range outputs {
+		n, ok := decoder[val]
+		if !ok {
+			return 0, 0, fmt.Errorf("invalid output value %07b", val)
+		}
+		value = 10*value + n
+
+		if n == 1 || n == 4 || n == 7 || n == 8 {
+			uniques++
+		}
+	}
+
+	return uniques, value, nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 8, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Entries []parse.Line
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	entries, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Puzzle{Entries: entries}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	var uniques int
+
+	for _, entry := range p.Entries {
+		u, _, err := EvaluateEntry(entry.Text)
+		if err != nil {
+			return "", entry.Wrap(err)
+		}
+		uniques += u
+	}
+
+	return strconv.Itoa(uniques), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	var values int
+
+	for _, entry := range p.Entries {
+		_, v, err := EvaluateEntry(entry.Text)
+		if err != nil {
+			return "", entry.Wrap(err)
+		}
+		values += v
+	}
+
+	return strconv.Itoa(values), nil
+}
@@ -0,0 +1,46 @@
+package day08
+
+import (
+	"bufio"
+	"math/rand"
+	"strings"
+)
+
+// Digits holds the segments lit for each digit on an intact display.
+var Digits = [10]string{"abcefg", "cf", "acdeg", "acdfg", "bcdf", "abdfg", "abdefg", "acf", "abcdefg", "abcdfg"}
+
+// Generate writes size entries, each with its own random wiring of the
+// segments.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 200
+	}
+
+	for i := 0; i < size; i++ {
+		wiring := rnd.Perm(7)
+
+		wire := func(digit int) string {
+			var segs []byte
+			for _, s := range Digits[digit] {
+				segs = append(segs, byte('a'+wiring[s-'a']))
+			}
+			rnd.Shuffle(len(segs), func(i, j int) { segs[i], segs[j] = segs[j], segs[i] })
+			return string(segs)
+		}
+
+		patterns := make([]string, 10)
+		for j, digit := range rnd.Perm(10) {
+			patterns[j] = wire(digit)
+		}
+
+		var output [4]string
+		for j := range output {
+			output[j] = wire(rnd.Intn(10))
+		}
+
+		w.WriteString(strings.Join(patterns, " "))
+		w.WriteString(" | ")
+		w.WriteString(strings.Join(output[:], " "))
+		w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,15 @@
+package day08
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var entryRx = regexp.MustCompile(`^([a-g]{2,7} ){10}\|( [a-g]{2,7}){4}$`)
+
+// Lint checks that each entry holds ten signal patterns and four output
+// digits.
+func Lint(input []byte) []lint.Diagnostic {
+	return lint.Pattern(lint.Lines(input), entryRx, "abcdefg |", "10 patterns, \"|\" and 4 digits")
+}
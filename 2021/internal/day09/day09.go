@@ -0,0 +1,169 @@
+// Solution for https://adventofcode.com/2021/day/9
+
+package day09
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+)
+
+const (
+	MaxHeight = 9
+)
+
+type Heightmap = grid.Grid[int]
+
+func IsLowPoint(h *Heightmap, p grid.Point) bool {
+	center := h.At(p)
+
+	for _, n := range h.Neighbors4(p) {
+		if h.At(n) <= center {
+			return false
+		}
+	}
+
+	return true
+}
+
+func ReadHeightmap(r io.Reader) (*Heightmap, error) {
+	return grid.Parse(r, grid.Digit)
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 9, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Heightmap *Heightmap
+
+	sink frame.Sink
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	h, err := ReadHeightmap(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Puzzle{Heightmap: h}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Record(sink frame.Sink) {
+	p.sink = sink
+}
+
+// Cell values in the frames of part 2; basins take the values from
+// firstBasin up to frameMax in turn.
+const (
+	unfilled   = 0
+	wall       = 1
+	firstBasin = 2
+	frameMax   = 16
+)
+
+// basinFrame returns the frame that emitBasin fills in, showing the walls
+// between basins, or nil if there is no sink.
+func (p *Puzzle) basinFrame() *frame.Frame {
+	if p.sink == nil {
+		return nil
+	}
+
+	h := p.Heightmap
+	f := &frame.Frame{
+		Label:   "walls",
+		Grid:    grid.New[uint8](h.Width, h.Height),
+		Max:     frameMax,
+		Chars:   ".#abcdefghijklmno",
+		Palette: frame.Palettes["regions"],
+	}
+
+	for i, height := range h.Cells {
+		if height == MaxHeight {
+			f.Grid.Cells[i] = wall
+		}
+	}
+
+	p.sink.Emit(f)
+
+	return f
+}
+
+// emitBasin adds the nth basin to f and sends it to the sink.
+func (p *Puzzle) emitBasin(f *frame.Frame, n int, basin []grid.Point) {
+	if f == nil {
+		return
+	}
+
+	for _, q := range basin {
+		f.Grid.Set(q, firstBasin+uint8((n-1)%(frameMax-firstBasin+1)))
+	}
+
+	f.Step = n
+	f.Label = fmt.Sprintf("basin of size %d", len(basin))
+	p.sink.Emit(f)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	h := p.Heightmap
+
+	var riskSum int
+
+	for i, height := range h.Cells {
+		if IsLowPoint(h, h.Point(i)) {
+			riskSum += 1 + height
+		}
+	}
+
+	return strconv.Itoa(riskSum), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	h := p.Heightmap.Clone()
+	f := p.basinFrame()
+
+	var sizes []int
+
+	for {
+		var stack []grid.Point
+
+		start, ok := h.Find(func(height int) bool { return height < MaxHeight })
+		if !ok {
+			break
+		}
+		h.Set(start, MaxHeight)
+
+		stack = append(stack, start)
+
+		for i := 0; i < len(stack); i++ {
+			for _, n := range h.Neighbors4(stack[i]) {
+				if h.At(n) != MaxHeight {
+					h.Set(n, MaxHeight)
+					stack = append(stack, n)
+				}
+			}
+		}
+
+		sizes = append(sizes, len(stack))
+		p.emitBasin(f, len(sizes), stack)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+
+	prod := 1
+
+	for i := 0; i < 3 && i < len(sizes); i++ {
+		prod *= sizes[i]
+	}
+
+	return strconv.Itoa(prod), nil
+}
@@ -0,0 +1,41 @@
+package day09
+
+import (
+	"bufio"
+	"math/rand"
+)
+
+// Generate writes a size×size heightmap of random heights. Walls of height 9
+// with a few gaps run along random rows and columns and split the map into
+// basins.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 100
+	}
+
+	h := make([][]byte, size)
+	for y := range h {
+		h[y] = make([]byte, size)
+		for x := range h[y] {
+			h[y][x] = byte('0' + rnd.Intn(MaxHeight))
+		}
+	}
+
+	for i := 0; i < size/4; i++ {
+		row, col := rnd.Intn(size), rnd.Intn(size)
+
+		for j := 0; j < size; j++ {
+			if rnd.Intn(8) != 0 {
+				h[row][j] = '0' + MaxHeight
+			}
+			if rnd.Intn(8) != 0 {
+				h[j][col] = '0' + MaxHeight
+			}
+		}
+	}
+
+	for _, row := range h {
+		w.Write(row)
+		w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,8 @@
+package day09
+
+import "codeberg.org/mhofmann/adventofcode/internal/lint"
+
+// Lint checks that the input is a rectangular heightmap.
+func Lint(input []byte) []lint.Diagnostic {
+	return lint.Grid(lint.Lines(input), "0123456789", 0, 0)
+}
@@ -0,0 +1,175 @@
+// Solution for https://adventofcode.com/2021/day/10
+
+package day10
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+func lastRune(stack []rune) rune {
+	if len(stack) == 0 {
+		return 0
+	}
+	return stack[len(stack)-1]
+}
+
+func ErrorScore(line string) (score int, err error) {
+	var stack []rune
+
+	for _, r := range line {
+		switch r {
+		case '(', '[', '{', '<':
+			stack = append(stack, r)
+		case ')':
+			if lastRune(stack) == '(' {
+				stack = stack[:len(stack)-1]
+			} else {
+				return 3, nil
+			}
+		case ']':
+			if lastRune(stack) == '[' {
+				stack = stack[:len(stack)-1]
+			} else {
+				return 57, nil
+			}
+		case '}':
+			if lastRune(stack) == '{' {
+				stack = stack[:len(stack)-1]
+			} else {
+				return 1197, nil
+			}
+		case '>':
+			if lastRune(stack) == '<' {
+				stack = stack[:len(stack)-1]
+			} else {
+				return 25137, nil
+			}
+		default:
+			return 0, fmt.Errorf("invalid character %q", r)
+		}
+	}
+
+	return 0, nil
+}
+
+func CompletionScore(line string) (score int, err error) {
+	var stack []rune
+
+	for _, r := range line {
+		switch r {
+		case '(', '[', '{', '<':
+			stack = append(stack, r)
+		case ')':
+			if lastRune(stack) == '(' {
+				stack = stack[:len(stack)-1]
+			} else {
+				return 0, nil
+			}
+		case ']':
+			if lastRune(stack) == '[' {
+				stack = stack[:len(stack)-1]
+			} else {
+				return 0, nil
+			}
+		case '}':
+			if lastRune(stack) == '{' {
+				stack = stack[:len(stack)-1]
+			} else {
+				return 0, nil
+			}
+		case '>':
+			if lastRune(stack) == '<' {
+				stack = stack[:len(stack)-1]
+			} else {
+				return 0, nil
+			}
+		default:
+			return 0, fmt.Errorf("invalid character %q", r)
+		}
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		if score > (math.MaxInt-4)/5 {
+			return 0, fmt.Errorf("completion score of %d unclosed chunks is too large", len(stack))
+		}
+
+		switch stack[i] {
+		case '(':
+			score = score*5 + 1
+		case '[':
+			score = score*5 + 2
+		case '{':
+			score = score*5 + 3
+		case '<':
+			score = score*5 + 4
+		}
+	}
+
+	return score, nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 10, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Lines []parse.Line
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Puzzle{Lines: lines}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	var errscore int
+
+	for _, line := range p.Lines {
+		score, err := ErrorScore(line.Text)
+		if err != nil {
+			return "", line.Wrap(err)
+		}
+		errscore += score
+	}
+
+	return strconv.Itoa(errscore), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	var compscores []int
+
+	for _, line := range p.Lines {
+		compscore, err := CompletionScore(line.Text)
+		if err != nil {
+			return "", line.Wrap(err)
+		}
+
+		if compscore > 0 {
+			compscores = append(compscores, compscore)
+		}
+	}
+
+	if len(compscores) == 0 {
+		return "", errors.New("no incomplete lines")
+	}
+
+	sort.Ints(compscores)
+
+	return strconv.Itoa(compscores[len(compscores)/2]), nil
+}
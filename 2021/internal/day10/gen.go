@@ -0,0 +1,50 @@
+package day10
+
+import (
+	"bufio"
+	"math/rand"
+)
+
+const (
+	openers = "([{<"
+	closers = ")]}>"
+
+	// Chunks are nested no deeper than in the official inputs, which keeps
+	// the completion scores of incomplete lines well within an int.
+	maxDepth = 20
+)
+
+// Generate writes size lines of chunks, about half of them corrupted and the
+// rest incomplete. The first line is always incomplete, as part 2 needs one.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 100
+	}
+
+	for i := 0; i < size; i++ {
+		corrupted := i > 0 && rnd.Intn(2) == 0
+		length := 20 + rnd.Intn(100)
+
+		var stack []int
+
+		for j := 0; j < length || len(stack) == 0; j++ {
+			if corrupted && j == length/2 && len(stack) > 0 {
+				wrong := (stack[len(stack)-1] + 1 + rnd.Intn(3)) % 4
+				w.WriteByte(closers[wrong])
+				break
+			}
+
+			if len(stack) == 0 || len(stack) < maxDepth && rnd.Intn(5) < 3 {
+				b := rnd.Intn(4)
+				w.WriteByte(openers[b])
+				stack = append(stack, b)
+				continue
+			}
+
+			w.WriteByte(closers[stack[len(stack)-1]])
+			stack = stack[:len(stack)-1]
+		}
+
+		w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,14 @@
+package day10
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var chunksRx = regexp.MustCompile(`^[()\[\]{}<>]+$`)
+
+// Lint checks that the lines consist of brackets only.
+func Lint(input []byte) []lint.Diagnostic {
+	return lint.Pattern(lint.Lines(input), chunksRx, "()[]{}<>", "a line of brackets")
+}
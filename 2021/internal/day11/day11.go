@@ -0,0 +1,203 @@
+// Solution for https://adventofcode.com/2021/day/11
+
+package day11
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+)
+
+const (
+	Steps    = 100
+	CaveSize = 10
+)
+
+type Octopus struct {
+	Energy    uint32
+	Lastflash uint32
+}
+
+type Cave = grid.Grid[Octopus]
+
+func ReadOctos(r io.Reader) (*Cave, error) {
+	return grid.Parse(r, func(b byte) (Octopus, error) {
+		energy, err := grid.Digit(b)
+		return Octopus{Energy: uint32(energy)}, err
+	})
+}
+
+func Step(octos *Cave, step uint32) (flashCount int) {
+	var stack []grid.Point
+
+	for i := range octos.Cells {
+		o := &octos.Cells[i]
+		o.Energy++
+		if o.Energy > 9 {
+			o.Lastflash = step
+			stack = append(stack, octos.Point(i))
+			flashCount++
+		}
+	}
+
+	for si := 0; si < len(stack); si++ {
+		for _, n := range octos.Neighbors8(stack[si]) {
+			o := octos.Ref(n)
+			o.Energy++
+			if o.Energy > 9 && o.Lastflash != step {
+				o.Lastflash = step
+				stack = append(stack, n)
+				flashCount++
+			}
+		}
+	}
+
+	for i := range octos.Cells {
+		if octos.Cells[i].Energy > 9 {
+			octos.Cells[i].Energy = 0
+		}
+	}
+
+	return flashCount
+}
+
+func AllFlashed(octos *Cave) bool {
+	for _, o := range octos.Cells {
+		if o.Energy != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 11, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Octos *Cave
+	Steps int
+
+	sink frame.Sink
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	octos, err := ReadOctos(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read octos: %w", err)
+	}
+
+	return &Puzzle{Octos: octos, Steps: Steps}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Params(fs *flag.FlagSet) {
+	fs.IntVar(&p.Steps, "steps", p.Steps, "count the flashes during the first `n` steps in part 1")
+}
+
+func (p *Puzzle) Record(sink frame.Sink) {
+	p.sink = sink
+}
+
+// Flashed is the cell value in frames of the octopuses that flashed in the
+// step, above all energy levels.
+const Flashed = 10
+
+// energyFrame returns the frame emit fills in for each step, or nil if there
+// is no sink.
+func (p *Puzzle) energyFrame() *frame.Frame {
+	if p.sink == nil {
+		return nil
+	}
+
+	return &frame.Frame{
+		Grid:    grid.New[uint8](p.Octos.Width, p.Octos.Height),
+		Max:     Flashed,
+		Chars:   "0123456789*",
+		Palette: frame.Palettes["energy"],
+	}
+}
+
+// emit sends the energy levels after step to the sink, with the octopuses
+// that flashed in the step marked as Flashed.
+func (p *Puzzle) emit(f *frame.Frame, octos *Cave, step uint32, flashes int) {
+	if f == nil {
+		return
+	}
+
+	for i, o := range octos.Cells {
+		if step > 0 && o.Lastflash == step {
+			f.Grid.Cells[i] = Flashed
+		} else {
+			f.Grid.Cells[i] = uint8(o.Energy)
+		}
+	}
+
+	f.Step = int(step)
+	f.Label = fmt.Sprintf("%d flashes", flashes)
+	p.sink.Emit(f)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	return p.Part1Context(context.Background())
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	return p.Part2Context(context.Background())
+}
+
+func (p *Puzzle) Part1Context(ctx context.Context) (string, error) {
+	octos := p.Octos.Clone()
+
+	if p.Steps < 0 {
+		return "", fmt.Errorf("invalid number of steps: %d", p.Steps)
+	}
+
+	var flashCount int
+
+	f := p.energyFrame()
+	p.emit(f, octos, 0, 0)
+
+	for step := uint32(1); step <= uint32(p.Steps); step++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		n := Step(octos, step)
+		flashCount += n
+		p.emit(f, octos, step, n)
+	}
+
+	return strconv.Itoa(flashCount), nil
+}
+
+// Part2Context runs until all octopuses flash at once, which some caves never
+// do, so it relies on ctx to end the search.
+func (p *Puzzle) Part2Context(ctx context.Context) (string, error) {
+	octos := p.Octos.Clone()
+
+	f := p.energyFrame()
+	p.emit(f, octos, 0, 0)
+
+	for step := uint32(1); ; step++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		n := Step(octos, step)
+		p.emit(f, octos, step, n)
+
+		if AllFlashed(octos) {
+			return strconv.FormatUint(uint64(step), 10), nil
+		}
+	}
+}
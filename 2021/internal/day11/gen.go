@@ -0,0 +1,52 @@
+package day11
+
+import (
+	"bufio"
+	"math/rand"
+
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+)
+
+// Part 2 only ends if all octopuses flash at once eventually, which about
+// half of the random caves of the official size do within a few hundred
+// steps. Generate tries this many caves for one that does within
+// syncSteps.
+const (
+	syncAttempts = 100
+	syncSteps    = 1000
+)
+
+func randomCave(rnd *rand.Rand, size int) *Cave {
+	c := grid.New[Octopus](size, size)
+	for i := range c.Cells {
+		c.Cells[i].Energy = uint32(rnd.Intn(10))
+	}
+	return c
+}
+
+func syncs(c *Cave) bool {
+	c = c.Clone()
+	for step := uint32(1); step <= syncSteps; step++ {
+		Step(c, step)
+		if AllFlashed(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Generate writes a size×size cave of random energy levels, preferring one
+// that syncs as described above. Caves other than CaveSize are valid for the
+// solver, but not for Lint, and rarely sync.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = CaveSize
+	}
+
+	c := randomCave(rnd, size)
+	for i := 1; i < syncAttempts && !syncs(c); i++ {
+		c = randomCave(rnd, size)
+	}
+
+	c.Print(w, func(o Octopus) string { return string(rune('0' + o.Energy)) })
+}
@@ -0,0 +1,9 @@
+package day11
+
+import "codeberg.org/mhofmann/adventofcode/internal/lint"
+
+// Lint checks that the input is a square cave of energy levels. The solver
+// copes with other sizes, but a short cave usually means a truncated input.
+func Lint(input []byte) []lint.Diagnostic {
+	return lint.Grid(lint.Lines(input), "0123456789", CaveSize, CaveSize)
+}
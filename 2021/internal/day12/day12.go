@@ -0,0 +1,174 @@
+// Solution for https://adventofcode.com/2021/day/12
+
+package day12
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+type Node struct {
+	Name    string
+	Visited bool
+	Edges   []*Node
+}
+
+func (n *Node) IsSmall() bool {
+	return isSmall(n.Name)
+}
+
+func isSmall(name string) bool {
+	return len(name) > 0 && name[0] >= 'a' && name[0] <= 'z'
+}
+
+func CountValidPaths(from *Node, allowTwice bool) int {
+	n, _ := CountValidPathsContext(context.Background(), from, allowTwice)
+	return n
+}
+
+// CountValidPathsContext is like CountValidPaths, but gives up with the error
+// of ctx once it is done, as the number of paths can explode on large graphs.
+func CountValidPathsContext(ctx context.Context, from *Node, allowTwice bool) (int, error) {
+	c := pathCounter{ctx: ctx}
+	return c.count(from, allowTwice)
+}
+
+// checkInterval is how many caves pathCounter visits between checks of its
+// context, which would take longer than the visits themselves.
+const checkInterval = 1 << 16
+
+type pathCounter struct {
+	ctx    context.Context
+	visits int
+}
+
+func (c *pathCounter) count(from *Node, allowTwice bool) (int, error) {
+	if c.visits++; c.visits%checkInterval == 0 {
+		if err := c.ctx.Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	if from.Name == "end" {
+		return 1, nil
+	}
+
+	prev := from.Visited
+	if prev {
+		if from.IsSmall() {
+			if !allowTwice || from.Name == "start" {
+				return 0, nil
+			}
+
+			allowTwice = false
+		}
+	}
+
+	from.Visited = true
+	defer func() { from.Visited = prev }()
+
+	var subpaths int
+	for _, n := range from.Edges {
+		paths, err := c.count(n, allowTwice)
+		if err != nil {
+			return 0, err
+		}
+		subpaths += paths
+	}
+
+	return subpaths, nil
+}
+
+func ReadGraph(r io.Reader) (nodes map[string]*Node, err error) {
+	nodes = make(map[string]*Node)
+
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range lines {
+		names := strings.FieldsFunc(l.Text, func(r rune) bool { return r == '-' })
+		if len(names) != 2 {
+			return nil, l.Errorf("invalid path %q", l.Text)
+		}
+
+		// Paths could run back and forth between two big caves forever
+		if !isSmall(names[0]) && !isSmall(names[1]) {
+			return nil, l.Errorf("big caves %s and %s are connected", names[0], names[1])
+		}
+
+		var path [2]*Node
+		for i := 0; i < 2; i++ {
+			path[i] = nodes[names[i]]
+			if path[i] == nil {
+				path[i] = &Node{
+					Name: names[i],
+				}
+				nodes[names[i]] = path[i]
+			}
+		}
+		path[0].Edges = append(path[0].Edges, path[1])
+		path[1].Edges = append(path[1].Edges, path[0])
+	}
+
+	return nodes, nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 12, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Start *Node
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	nodes, err := ReadGraph(r)
+	if err != nil {
+		return nil, err
+	}
+
+	start := nodes["start"]
+	if start == nil {
+		return nil, fmt.Errorf("no start cave")
+	}
+
+	return &Puzzle{Start: start}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	return p.Part1Context(context.Background())
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	return p.Part2Context(context.Background())
+}
+
+func (p *Puzzle) Part1Context(ctx context.Context) (string, error) {
+	n, err := CountValidPathsContext(ctx, p.Start, false)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(n), nil
+}
+
+func (p *Puzzle) Part2Context(ctx context.Context) (string, error) {
+	n, err := CountValidPathsContext(ctx, p.Start, true)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(n), nil
+}
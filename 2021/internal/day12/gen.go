@@ -0,0 +1,54 @@
+package day12
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// caveName returns a distinct name for cave i, made of letters from first
+// on, that is neither start nor end.
+func caveName(i int, first byte) string {
+	name := []byte{first + byte(i%26)}
+	for i /= 26; i > 0; i /= 26 {
+		name = append(name, first+byte(i%26))
+	}
+
+	if s := string(name); s == "start" || s == "end" {
+		return s + s
+	}
+
+	return string(name)
+}
+
+// Generate writes a cave system of size small caves, which form a chain from
+// start to end with a few shortcuts. One big cave per four small ones
+// connects two of them, so that the number of paths stays manageable for
+// the official size but grows quickly with the depth of the chain.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 10
+	}
+
+	chain := []string{"start"}
+	for i := 0; i < size; i++ {
+		chain = append(chain, caveName(i, 'a'))
+	}
+	chain = append(chain, "end")
+
+	for i := 1; i < len(chain); i++ {
+		fmt.Fprintf(w, "%s-%s\n", chain[i-1], chain[i])
+
+		if i > 1 && rnd.Intn(4) == 0 {
+			fmt.Fprintf(w, "%s-%s\n", chain[i-2], chain[i])
+		}
+	}
+
+	for i := 0; i < size/4+1; i++ {
+		big := caveName(i, 'A')
+
+		for j := 0; j < 2; j++ {
+			fmt.Fprintf(w, "%s-%s\n", big, chain[1+rnd.Intn(len(chain)-2)])
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package day12
+
+import (
+	"regexp"
+	"strings"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var pathRx = regexp.MustCompile(`^[a-zA-Z]+-[a-zA-Z]+$`)
+
+// Lint checks that each line connects two caves and that there is a start
+// and an end cave.
+func Lint(input []byte) []lint.Diagnostic {
+	lines := lint.Lines(input)
+
+	diags := lint.Pattern(lines, pathRx, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-", "\"<cave>-<cave>\"")
+
+	caves := make(map[string]bool)
+	for _, l := range lines {
+		if !pathRx.MatchString(l.Text) {
+			continue
+		}
+
+		names := strings.Split(l.Text, "-")
+		if !isSmall(names[0]) && !isSmall(names[1]) {
+			diags = append(diags, lint.Errorf(l.Num, 0, "big caves %s and %s are connected", names[0], names[1]))
+		}
+
+		caves[names[0]], caves[names[1]] = true, true
+	}
+
+	for _, name := range []string{"start", "end"} {
+		if !caves[name] {
+			diags = append(diags, lint.Diagnostic{Msg: "no " + name + " cave"})
+		}
+	}
+
+	return diags
+}
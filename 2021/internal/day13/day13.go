@@ -0,0 +1,247 @@
+// Solution for https://adventofcode.com/2021/day/13
+
+package day13
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/collections"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+	"codeberg.org/mhofmann/adventofcode/internal/geom"
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+	"codeberg.org/mhofmann/adventofcode/internal/ocr"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+type Point = geom.Point[int]
+
+type PointSet = collections.Set[Point]
+
+type Axis int
+
+const (
+	XAxis Axis = iota
+	YAxis
+)
+
+type FoldCmd struct {
+	Axis Axis
+	Pos  int
+}
+
+// Exec folds the points beyond the fold line over to the other side. Points
+// further away from the line than the paper's left or top edge end up at
+// negative coordinates.
+func (cmd FoldCmd) Exec(points PointSet) {
+	var newpoint Point
+
+	for oldpoint := range points {
+		if cmd.Axis == XAxis {
+			if oldpoint.X < cmd.Pos {
+				continue
+			}
+			newpoint.X = 2*cmd.Pos - oldpoint.X
+			newpoint.Y = oldpoint.Y
+		} else {
+			if oldpoint.Y < cmd.Pos {
+				continue
+			}
+			newpoint.X = oldpoint.X
+			newpoint.Y = 2*cmd.Pos - oldpoint.Y
+		}
+		points.Remove(oldpoint)
+		points.Add(newpoint)
+	}
+}
+
+const foldPrefix = "fold along "
+
+func ParseFoldCmd(s string) (FoldCmd, error) {
+	var cmd FoldCmd
+
+	n := len(foldPrefix)
+	if !strings.HasPrefix(s, foldPrefix) || len(s) < n+2 || s[n+1] != '=' {
+		return cmd, fmt.Errorf("invalid fold command %q", s)
+	}
+
+	switch s[n] {
+	case 'x':
+		cmd.Axis = XAxis
+	case 'y':
+		cmd.Axis = YAxis
+	default:
+		return cmd, fmt.Errorf("invalid fold axis in %q", s)
+	}
+
+	pos, err := strconv.ParseInt(strings.TrimSpace(s[n+2:]), 10, 32)
+	if err != nil {
+		return cmd, fmt.Errorf("invalid fold position in %q", s)
+	}
+	cmd.Pos = int(pos)
+
+	return cmd, nil
+}
+
+func ParseInput(r io.Reader) (PointSet, []FoldCmd, error) {
+	paras, err := parse.Paragraphs(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(paras) != 2 {
+		return nil, nil, fmt.Errorf("expected dots and fold instructions separated by a blank line")
+	}
+
+	points := make(PointSet)
+
+	for _, l := range paras[0] {
+		var x, y int
+		if err := l.Scanf("%d,%d", &x, &y); err != nil {
+			return nil, nil, err
+		}
+
+		points.Add(geom.Pt(x, y))
+	}
+
+	var cmds []FoldCmd
+
+	for _, l := range paras[1] {
+		cmd, err := ParseFoldCmd(l.Text)
+		if err != nil {
+			return nil, nil, l.Wrap(err)
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	return points, cmds, nil
+}
+
+func PrintPoints(w io.Writer, points PointSet) {
+	// Start with a box around the top left corner of the paper, so that
+	// empty columns or rows there are printed as well
+	var box geom.Box[int]
+
+	for p := range points {
+		box = box.Extend(p)
+	}
+
+	for y := box.Min.Y; y <= box.Max.Y; y++ {
+		for x := box.Min.X; x <= box.Max.X; x++ {
+			if points.Contains(geom.Pt(x, y)) {
+				fmt.Fprint(w, "#")
+			} else {
+				fmt.Fprint(w, ".")
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// PaperFrame returns a frame with a cell of 1 for each dot, covering the
+// same part of the paper as PrintPoints.
+func PaperFrame(points PointSet) *frame.Frame {
+	var box geom.Box[int]
+
+	for p := range points {
+		box = box.Extend(p)
+	}
+
+	f := &frame.Frame{
+		Grid:    grid.New[uint8](box.Width(), box.Height()),
+		Origin:  box.Min,
+		Max:     1,
+		Chars:   ".#",
+		Palette: frame.Palettes["paper"],
+	}
+
+	for p := range points {
+		f.Grid.Set(p.Sub(box.Min), 1)
+	}
+
+	return f
+}
+
+// ReadCode returns the letters drawn by points, which must start at the left
+// edge of the paper.
+func ReadCode(points PointSet) (string, error) {
+	return ocr.Read(PaperFrame(points).Grid)
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 13, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Points PointSet
+	Folds  []FoldCmd
+
+	sink frame.Sink
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	points, cmds, err := ParseInput(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cmds) == 0 {
+		return nil, fmt.Errorf("no fold commands")
+	}
+
+	return &Puzzle{Points: points, Folds: cmds}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Record(sink frame.Sink) {
+	p.sink = sink
+}
+
+// fold executes the fold commands on points and emits a frame of the paper
+// before the first and after each one if there is a sink.
+func (p *Puzzle) fold(points PointSet, cmds []FoldCmd) {
+	if p.sink != nil {
+		p.sink.Emit(PaperFrame(points))
+	}
+
+	for i, cmd := range cmds {
+		cmd.Exec(points)
+
+		if p.sink != nil {
+			f := PaperFrame(points)
+			f.Step = i + 1
+			f.Label = fmt.Sprintf("%s%c=%d", foldPrefix, "xy"[cmd.Axis], cmd.Pos)
+			p.sink.Emit(f)
+		}
+	}
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	points := p.Points.Clone()
+	p.fold(points, p.Folds[:1])
+
+	return strconv.Itoa(len(points)), nil
+}
+
+// Part2 returns the code on the folded paper, or the dots that make it up
+// along with an aoc.Warning if they do not form known letters.
+func (p *Puzzle) Part2() (string, error) {
+	points := p.Points.Clone()
+	p.fold(points, p.Folds)
+
+	code, err := ReadCode(points)
+	if err != nil {
+		var sb strings.Builder
+		PrintPoints(&sb, points)
+
+		return sb.String(), aoc.Warning(fmt.Sprintf("cannot read the code: %v", err))
+	}
+
+	return code, nil
+}
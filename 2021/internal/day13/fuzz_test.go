@@ -0,0 +1,38 @@
+package day13
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func FuzzParseFoldCmd(f *testing.F) {
+	files, _ := filepath.Glob("../days/testdata/day13/*.txt")
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			f.Add(line)
+		}
+	}
+	f.Add("fold along x=-1")
+	f.Add("fold along z=1")
+	f.Add("fold along y=")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		cmd, err := ParseFoldCmd(s)
+		if err != nil {
+			return
+		}
+
+		axis := "xy"[cmd.Axis]
+		again, err := ParseFoldCmd(fmt.Sprintf("fold along %c=%d", axis, cmd.Pos))
+		if err != nil || again != cmd {
+			t.Errorf("ParseFoldCmd(%q) = %v, which does not survive formatting: %v, %v", s, cmd, again, err)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package day13
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+
+	"codeberg.org/mhofmann/adventofcode/internal/ocr"
+)
+
+// The size of the paper after all folds in the official inputs, which holds
+// eight letters.
+const (
+	CodeWidth  = 40
+	CodeHeight = 6
+)
+
+// Generate writes the dots of eight random letters in the small font, and
+// the fold instructions of the official inputs: five folds along x and seven
+// along y, each through the middle of the paper. The letters are drawn on the
+// folded paper first and then unfolded, each dot landing on either half at
+// random, so none lies on a fold line. Every dot of the letters is unfolded
+// at least once, and more copies are added until there are size dots.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 800
+	}
+
+	var folds []FoldCmd
+
+	width, height := CodeWidth, CodeHeight
+	for i := 0; i < 12; i++ {
+		if i%2 == 0 && i < 10 {
+			folds = append(folds, FoldCmd{Axis: XAxis, Pos: width})
+			width = 2*width + 1
+		} else {
+			folds = append(folds, FoldCmd{Axis: YAxis, Pos: height})
+			height = 2*height + 1
+		}
+	}
+
+	// The folds were collected from the inside out
+	for i, j := 0, len(folds)-1; i < j; i, j = i+1, j-1 {
+		folds[i], folds[j] = folds[j], folds[i]
+	}
+
+	font := ocr.Small
+	letters := []rune(font.Letters)
+
+	text := make([]rune, CodeWidth/(font.Width+font.Spacing))
+	for i := range text {
+		text[i] = letters[rnd.Intn(len(letters))]
+	}
+
+	g, err := font.Draw(string(text))
+	if err != nil {
+		panic(err)
+	}
+
+	var code []Point
+	for i, v := range g.Cells {
+		if v != 0 {
+			code = append(code, Point{X: i % g.Width, Y: i / g.Width})
+		}
+	}
+
+	if size < len(code) {
+		size = len(code)
+	}
+	if max := len(code) << len(folds); size > max {
+		size = max
+	}
+
+	dots := make(PointSet)
+
+	for i := 0; len(dots) < size; i++ {
+		// Unfold each dot of the code once before picking them at random
+		var p Point
+		if i < len(code) {
+			p = code[i]
+		} else {
+			p = code[rnd.Intn(len(code))]
+		}
+
+		for j := len(folds) - 1; j >= 0; j-- {
+			if rnd.Intn(2) == 0 {
+				continue
+			}
+
+			if f := folds[j]; f.Axis == XAxis {
+				p.X = 2*f.Pos - p.X
+			} else {
+				p.Y = 2*f.Pos - p.Y
+			}
+		}
+
+		if dots.Contains(p) {
+			continue
+		}
+		dots.Add(p)
+
+		fmt.Fprintf(w, "%d,%d\n", p.X, p.Y)
+	}
+
+	w.WriteByte('\n')
+
+	for _, f := range folds {
+		axis := 'x'
+		if f.Axis == YAxis {
+			axis = 'y'
+		}
+		fmt.Fprintf(w, "%s%c=%d\n", foldPrefix, axis, f.Pos)
+	}
+}
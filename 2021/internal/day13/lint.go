@@ -0,0 +1,26 @@
+package day13
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var (
+	dotRx  = regexp.MustCompile(`^\d+,\d+$`)
+	foldRx = regexp.MustCompile(`^fold along [xy]=\d+$`)
+)
+
+// Lint checks that the dots are followed by fold instructions, separated by
+// a blank line.
+func Lint(input []byte) []lint.Diagnostic {
+	paras := lint.Paragraphs(input)
+	if len(paras) != 2 {
+		return []lint.Diagnostic{{Msg: "expected dots and fold instructions separated by a blank line"}}
+	}
+
+	diags := lint.Pattern(paras[0], dotRx, "0123456789,", "\"x,y\"")
+	diags = append(diags, lint.Pattern(paras[1], foldRx, "fold alongxy=0123456789", "\"fold along x|y=<n>\"")...)
+
+	return diags
+}
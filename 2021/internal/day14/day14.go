@@ -0,0 +1,207 @@
+// Solution for https://adventofcode.com/2021/day/14
+
+package day14
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/collections"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+const (
+	P1Steps = 10
+	P2Steps = 40
+)
+
+func ParseInput(r io.Reader) (template string, rules map[string]byte, err error) {
+	paras, err := parse.Paragraphs(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(paras) == 0 {
+		return "", nil, fmt.Errorf("empty input")
+	}
+
+	if len(paras) > 2 || len(paras[0]) > 1 {
+		return "", nil, fmt.Errorf("expected template and rules separated by a blank line")
+	}
+
+	template = strings.TrimSpace(paras[0][0].Text)
+	rules = make(map[string]byte)
+
+	if len(paras) == 1 {
+		return template, rules, nil
+	}
+
+	for _, l := range paras[1] {
+		var (
+			pair   string
+			insert rune
+		)
+
+		if err := l.Scanf("%2s -> %c", &pair, &insert); err != nil {
+			return "", nil, err
+		}
+
+		if len(pair) != 2 || insert >= utf8.RuneSelf {
+			return "", nil, l.Errorf("invalid rule %q", l.Text)
+		}
+
+		rules[pair] = byte(insert)
+	}
+
+	return template, rules, nil
+}
+
+func PairCounts(s string) collections.Counter[string] {
+	counts := make(collections.Counter[string])
+
+	for i := 0; i < len(s)-1; i++ {
+		counts.Add(s[i:i+2], 1)
+	}
+
+	return counts
+}
+
+func ApplyRules(pairs collections.Counter[string], rules map[string]byte) collections.Counter[string] {
+	newpairs := make(collections.Counter[string])
+
+	for pair, count := range pairs {
+		b, ok := rules[pair]
+		if !ok {
+			newpairs.Add(pair, count)
+			continue
+		}
+
+		new1 := string([]byte{pair[0], b})
+		new2 := string([]byte{b, pair[1]})
+
+		newpairs.Add(new1, count)
+		newpairs.Add(new2, count)
+	}
+
+	return newpairs
+}
+
+// ElementCounts counts the elements of the polymer made up of pairs. Each
+// element but the last is the first of a pair.
+func ElementCounts(pairs collections.Counter[string], lastchar byte) collections.Counter[byte] {
+	counts := make(collections.Counter[byte])
+	counts.Add(lastchar, 1)
+
+	for pair, count := range pairs {
+		counts.Add(pair[0], count)
+	}
+
+	return counts
+}
+
+func MinMaxChar(pairs collections.Counter[string], lastchar byte) (min, max int) {
+	counts := ElementCounts(pairs, lastchar).Counts()
+	return counts[len(counts)-1].N, counts[0].N
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 14, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Template string
+	Rules    map[string]byte
+	Steps    [2]int // Applied in each part, the official numbers unless changed
+
+	sink frame.Sink
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	template, rules, err := ParseInput(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(template) == 0 {
+		return nil, fmt.Errorf("empty polymer template")
+	}
+
+	return &Puzzle{Template: template, Rules: rules, Steps: [2]int{P1Steps, P2Steps}}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Params(fs *flag.FlagSet) {
+	fs.Func("steps", "apply the rules `n` times in both parts instead of 10 and 40", func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid number of steps %q", s)
+		}
+
+		p.Steps = [2]int{n, n}
+
+		return nil
+	})
+}
+
+func (p *Puzzle) Record(sink frame.Sink) {
+	p.sink = sink
+}
+
+// emit sends the element counts of the polymer after step to the sink, if
+// there is one.
+func (p *Puzzle) emit(step int, pairs collections.Counter[string]) {
+	if p.sink == nil {
+		return
+	}
+
+	var sb strings.Builder
+	for _, c := range ElementCounts(pairs, p.Template[len(p.Template)-1]).Counts() {
+		fmt.Fprintf(&sb, "%c %d\n", c.Value, c.N)
+	}
+
+	p.sink.Emit(&frame.Frame{Step: step, Label: fmt.Sprintf("length %d", pairs.Total()+1), Text: sb.String()})
+}
+
+func (p *Puzzle) Polymerize(steps int) (int, error) {
+	if steps < 0 {
+		return 0, fmt.Errorf("invalid number of steps: %d", steps)
+	}
+
+	pairs := PairCounts(p.Template)
+	p.emit(0, pairs)
+
+	for i := 0; i < steps; i++ {
+		pairs = ApplyRules(pairs, p.Rules)
+		p.emit(i+1, pairs)
+	}
+
+	min, max := MinMaxChar(pairs, p.Template[len(p.Template)-1])
+	return max - min, nil
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	n, err := p.Polymerize(p.Steps[0])
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(n), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	n, err := p.Polymerize(p.Steps[1])
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(n), nil
+}
@@ -0,0 +1,34 @@
+package day14
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Elements is how many different elements the official inputs use.
+const Elements = 10
+
+// Generate writes a polymer template of size elements and an insertion rule
+// for every pair of elements.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 20
+	}
+
+	var elements []byte
+	for _, i := range rnd.Perm(26)[:Elements] {
+		elements = append(elements, byte('A'+i))
+	}
+
+	for i := 0; i < size; i++ {
+		w.WriteByte(elements[rnd.Intn(Elements)])
+	}
+	w.WriteString("\n\n")
+
+	for _, a := range elements {
+		for _, b := range elements {
+			fmt.Fprintf(w, "%c%c -> %c\n", a, b, elements[rnd.Intn(Elements)])
+		}
+	}
+}
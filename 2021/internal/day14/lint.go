@@ -0,0 +1,40 @@
+package day14
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+)
+
+var (
+	templateRx = regexp.MustCompile(`^[A-Z]+$`)
+	ruleRx     = regexp.MustCompile(`^[A-Z]{2} -> [A-Z]$`)
+)
+
+// Lint checks that the polymer template is followed by insertion rules,
+// separated by a blank line.
+func Lint(input []byte) []lint.Diagnostic {
+	paras := lint.Paragraphs(input)
+	if len(paras) != 2 {
+		return []lint.Diagnostic{{Msg: "expected a template and insertion rules separated by a blank line"}}
+	}
+
+	diags := lint.Count(paras[0], 1, 1, "template lines")
+	diags = append(diags, lint.Pattern(paras[0], templateRx, "ABCDEFGHIJKLMNOPQRSTUVWXYZ", "a polymer template")...)
+
+	seen := make(map[string]int)
+	for _, l := range paras[1] {
+		if !ruleRx.MatchString(l.Text) {
+			continue
+		}
+
+		if prev, dup := seen[l.Text[:2]]; dup {
+			diags = append(diags, lint.Errorf(l.Num, 1, "rule for %s already given in line %d", l.Text[:2], prev))
+		}
+		seen[l.Text[:2]] = l.Num
+	}
+
+	diags = append(diags, lint.Pattern(paras[1], ruleRx, "ABCDEFGHIJKLMNOPQRSTUVWXYZ ->", "\"XY -> Z\"")...)
+
+	return diags
+}
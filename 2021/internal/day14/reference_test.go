@@ -0,0 +1,66 @@
+package day14
+
+import (
+	"testing"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc/aoctest"
+)
+
+// referencePolymerize builds the whole polymer.
+func referencePolymerize(template string, rules map[string]byte, steps int) int {
+	polymer := []byte(template)
+
+	for i := 0; i < steps; i++ {
+		next := []byte{polymer[0]}
+
+		for j := 1; j < len(polymer); j++ {
+			if b, ok := rules[string(polymer[j-1:j+1])]; ok {
+				next = append(next, b)
+			}
+			next = append(next, polymer[j])
+		}
+
+		polymer = next
+	}
+
+	counts := make(map[byte]int)
+	for _, b := range polymer {
+		counts[b]++
+	}
+
+	min, max := len(polymer), 0
+	for _, n := range counts {
+		if n < min {
+			min = n
+		}
+		if n > max {
+			max = n
+		}
+	}
+
+	return max - min
+}
+
+func TestPolymerizeReference(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		p := aoctest.Generate[*Puzzle](t, 14, seed, 1+int(seed)%5)
+
+		// Drop some rules, as pairs without one stay as they are
+		for pair := range p.Rules {
+			if pair[0] == pair[1] {
+				delete(p.Rules, pair)
+			}
+		}
+
+		for steps := 1; steps <= P1Steps; steps++ {
+			got, err := p.Polymerize(steps)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := referencePolymerize(p.Template, p.Rules, steps); got != want {
+				t.Errorf("seed %d, %d steps: got %d, want %d", seed, steps, got, want)
+			}
+		}
+	}
+}
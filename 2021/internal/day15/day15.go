@@ -0,0 +1,166 @@
+// Solution for https://adventofcode.com/2021/day/15
+// Implements A* over a square grid
+
+package day15
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+	"codeberg.org/mhofmann/adventofcode/internal/search"
+)
+
+// Tiles is how many times the cave is repeated in each direction in part 2.
+const Tiles = 5
+
+type Cave struct {
+	grid.Grid[uint8]
+}
+
+func (c *Cave) CostBetween(start, end grid.Point) (uint, error) {
+	_, cost, err := c.PathBetween(context.Background(), start, end)
+	return cost, err
+}
+
+// PathBetween returns the points on the path of lowest risk from start to end
+// and its total risk. It gives up with the error of ctx once it is done.
+func (c *Cave) PathBetween(ctx context.Context, start, end grid.Point) ([]grid.Point, uint, error) {
+	s := search.Search[grid.Point]{
+		Neighbors: c.Neighbors4,
+		Cost:      func(_, to grid.Point) int { return int(c.At(to)) },
+		Heuristic: func(p grid.Point) int { return end.Manhattan(p) },
+		Goal:      func(p grid.Point) bool { return p == end },
+		Visit:     func(grid.Point, int) bool { return ctx.Err() == nil },
+	}
+
+	r := s.AStar(start)
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	if !r.Found {
+		return nil, 0, fmt.Errorf("no path from %d,%d to %d,%d", start.X, start.Y, end.X, end.Y)
+	}
+
+	return r.Path(), uint(r.Cost), nil
+}
+
+func (c *Cave) Expanded(ntimes int) *Cave {
+	nc := &Cave{Grid: *grid.New[uint8](c.Width*ntimes, c.Height*ntimes)}
+
+	for i := range nc.Cells {
+		p := nc.Point(i)
+		d := p.X/c.Width + p.Y/c.Height
+
+		// Add in int, as d exceeds a uint8 for large numbers of tiles
+		risk := int(c.At(grid.Point{X: p.X % c.Width, Y: p.Y % c.Height})) - 1
+		nc.Cells[i] = uint8((risk+d)%9 + 1)
+	}
+
+	return nc
+}
+
+func ReadCave(r io.Reader) (*Cave, error) {
+	g, err := grid.Parse(r, func(b byte) (uint8, error) {
+		if b < '1' || b > '9' {
+			return 0, fmt.Errorf("invalid risk level %q", b)
+		}
+		return b - '0', nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cave{Grid: *g}, nil
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 15, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Cave  *Cave
+	Tiles int
+
+	sink frame.Sink
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	cave, err := ReadCave(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Puzzle{Cave: cave, Tiles: Tiles}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Params(fs *flag.FlagSet) {
+	fs.IntVar(&p.Tiles, "tiles", p.Tiles, "repeat the cave `n` times in each direction in part 2")
+}
+
+func (p *Puzzle) Record(sink frame.Sink) {
+	p.sink = sink
+}
+
+// Route is the cell value of the path in frames, above all risk levels.
+const Route = 10
+
+// lowestRisk returns the total risk of the best path from the top left to
+// the bottom right of cave and emits a frame of it if there is a sink.
+func (p *Puzzle) lowestRisk(ctx context.Context, cave *Cave) (string, error) {
+	start := grid.Point{X: 0, Y: 0}
+	end := grid.Point{X: cave.Width - 1, Y: cave.Height - 1}
+
+	path, cost, err := cave.PathBetween(ctx, start, end)
+	if err != nil {
+		return "", err
+	}
+
+	if p.sink != nil {
+		f := &frame.Frame{
+			Label:   fmt.Sprintf("total risk %d", cost),
+			Grid:    cave.Grid.Clone(),
+			Max:     Route,
+			Chars:   "0123456789*",
+			Palette: frame.Palettes["marked"],
+		}
+
+		for _, q := range path {
+			f.Grid.Set(q, Route)
+		}
+
+		p.sink.Emit(f)
+	}
+
+	return strconv.FormatUint(uint64(cost), 10), nil
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	return p.Part1Context(context.Background())
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	return p.Part2Context(context.Background())
+}
+
+func (p *Puzzle) Part1Context(ctx context.Context) (string, error) {
+	return p.lowestRisk(ctx, p.Cave)
+}
+
+func (p *Puzzle) Part2Context(ctx context.Context) (string, error) {
+	if p.Tiles < 1 {
+		return "", errors.New("the cave must be repeated at least once")
+	}
+
+	return p.lowestRisk(ctx, p.Cave.Expanded(p.Tiles))
+}
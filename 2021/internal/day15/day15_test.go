@@ -0,0 +1,22 @@
+package day15
+
+import (
+	"testing"
+
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+)
+
+func TestExpanded(t *testing.T) {
+	c := &Cave{Grid: *grid.New[uint8](1, 1)}
+	c.Cells[0] = 9
+
+	e := c.Expanded(300)
+
+	// Each tile adds one to the risk, wrapping from 9 back to 1
+	for _, p := range []grid.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 299, Y: 0}, {X: 299, Y: 299}} {
+		want := uint8((8+p.X+p.Y)%9 + 1)
+		if got := e.At(p); got != want {
+			t.Errorf("risk at %d,%d: got %d, want %d", p.X, p.Y, got, want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package day15
+
+import (
+	"bufio"
+	"math/rand"
+)
+
+// Generate writes a size×size cave of random risk levels.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 100
+	}
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			w.WriteByte(byte('1' + rnd.Intn(9)))
+		}
+		w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,8 @@
+package day15
+
+import "codeberg.org/mhofmann/adventofcode/internal/lint"
+
+// Lint checks that the input is a rectangular map of risk levels.
+func Lint(input []byte) []lint.Diagnostic {
+	return lint.Grid(lint.Lines(input), "123456789", 0, 0)
+}
@@ -0,0 +1,324 @@
+// Solution for https://adventofcode.com/2021/day/16
+
+package day16
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+)
+
+type LengthType uint8
+
+const (
+	BitLength LengthType = iota
+	PacketCount
+)
+
+type PacketType uint8
+
+const (
+	SumType PacketType = iota
+	ProductType
+	MinimumType
+	MaximumType
+	LiteralType
+	GreaterType
+	LesserType
+	EqualType
+)
+
+const (
+	HeaderBits      = 6
+	BitLengthBits   = 15
+	PacketCountBits = 11
+)
+
+var ErrTruncated = errors.New("truncated packet")
+
+// checkBits returns ErrTruncated if data does not hold n bits from bitpos on.
+func checkBits(data []byte, bitpos, n uint) error {
+	if bitpos+n > uint(len(data))*8 {
+		return ErrTruncated
+	}
+	return nil
+}
+
+type Header struct {
+	Version uint8
+	TypeID  PacketType
+}
+
+func ReadHeader(data []byte, bitpos uint) (Header, error) {
+	var h Header
+
+	if err := checkBits(data, bitpos, HeaderBits); err != nil {
+		return h, err
+	}
+
+	h.Version = Bit(data, bitpos+0) << 2
+	h.Version |= Bit(data, bitpos+1) << 1
+	h.Version |= Bit(data, bitpos+2)
+
+	id := Bit(data, bitpos+3) << 2
+	id |= Bit(data, bitpos+4) << 1
+	id |= Bit(data, bitpos+5)
+	h.TypeID = PacketType(id)
+
+	return h, nil
+}
+
+type Packet struct {
+	Header
+	Value uint
+	Sub   []*Packet
+}
+
+func ReadPacket(data []byte, bitpos, maxbits, maxsubs uint) (*Packet, uint, error) {
+	h, err := ReadHeader(data, bitpos)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if h.TypeID == LiteralType {
+		v, n, err := ReadVarUint(data, bitpos+HeaderBits)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &Packet{Header: h, Value: v}, n + HeaderBits, nil
+	}
+
+	current := bitpos + HeaderBits
+	if err := checkBits(data, current, 1); err != nil {
+		return nil, 0, err
+	}
+	ltype := LengthType(Bit(data, current))
+	current++
+
+	var subs []*Packet
+
+	if ltype == BitLength {
+		max, err := ReadFixedUint(data, current, BitLengthBits)
+		if err != nil {
+			return nil, 0, err
+		}
+		if maxbits > 0 && max > maxbits {
+			return nil, 0, fmt.Errorf("sub-packets at bit %d exceed the enclosing packet", current)
+		}
+		current += BitLengthBits
+
+		for max > 0 {
+			p, n, err := ReadPacket(data, current, max, 0)
+			if err != nil {
+				return nil, 0, err
+			}
+			if n > max {
+				return nil, 0, fmt.Errorf("sub-packet at bit %d exceeds the enclosing packet", current)
+			}
+
+			subs = append(subs, p)
+			current += n
+			max -= n
+		}
+	}
+
+	if ltype == PacketCount {
+		npkg, err := ReadFixedUint(data, current, PacketCountBits)
+		if err != nil {
+			return nil, 0, err
+		}
+		if maxsubs > 0 && npkg > maxsubs {
+			return nil, 0, fmt.Errorf("too many sub-packets at bit %d", current)
+		}
+		current += PacketCountBits
+
+		for npkg > 0 {
+			p, n, err := ReadPacket(data, current, 0, 0)
+			if err != nil {
+				return nil, 0, err
+			}
+			subs = append(subs, p)
+			current += n
+			npkg--
+		}
+	}
+
+	switch {
+	case h.TypeID >= GreaterType && len(subs) != 2:
+		return nil, 0, fmt.Errorf("comparison packet at bit %d has %d sub-packets", bitpos, len(subs))
+	case len(subs) == 0:
+		return nil, 0, fmt.Errorf("operator packet at bit %d has no sub-packets", bitpos)
+	}
+
+	return &Packet{Header: h, Sub: subs}, current - bitpos, nil
+}
+
+func (p *Packet) Print(prefix string) {
+	fmt.Printf("%sVersion: %d\n", prefix, p.Version)
+	fmt.Printf("%sTypeID: %d\n", prefix, p.TypeID)
+
+	if p.TypeID == LiteralType {
+		fmt.Printf("%sValue: %d\n", prefix, p.Value)
+	}
+
+	for _, sub := range p.Sub {
+		sub.Print(prefix + "  ")
+	}
+}
+
+func (p *Packet) SumOfVersions() uint {
+	sum := uint(p.Version)
+
+	for _, sub := range p.Sub {
+		sum += sub.SumOfVersions()
+	}
+
+	return sum
+}
+
+func (p *Packet) Evaluate() (value uint) {
+	switch p.TypeID {
+	case SumType:
+		for _, sub := range p.Sub {
+			value += sub.Evaluate()
+		}
+	case ProductType:
+		value = 1
+		for _, sub := range p.Sub {
+			value *= sub.Evaluate()
+		}
+	case MinimumType:
+		value = ^uint(0)
+		for _, sub := range p.Sub {
+			e := sub.Evaluate()
+			if e < value {
+				value = e
+			}
+		}
+	case MaximumType:
+		for _, sub := range p.Sub {
+			e := sub.Evaluate()
+			if e > value {
+				value = e
+			}
+		}
+	case LiteralType:
+		value = p.Value
+	case GreaterType:
+		if p.Sub[0].Evaluate() > p.Sub[1].Evaluate() {
+			value = 1
+		}
+	case LesserType:
+		if p.Sub[0].Evaluate() < p.Sub[1].Evaluate() {
+			value = 1
+		}
+	case EqualType:
+		if p.Sub[0].Evaluate() == p.Sub[1].Evaluate() {
+			value = 1
+		}
+	default:
+		panic("unknown packet type")
+	}
+
+	return value
+}
+
+func ReadVarUint(data []byte, bitpos uint) (u uint, bits uint, err error) {
+	current := bitpos
+	cont := uint8(1)
+
+	for cont != 0 {
+		if err := checkBits(data, current, 5); err != nil {
+			return 0, 0, err
+		}
+
+		if u > math.MaxUint>>4 {
+			return 0, 0, fmt.Errorf("literal value at bit %d is too large", bitpos)
+		}
+
+		cont = Bit(data, current)
+
+		var nibble uint8
+		nibble = Bit(data, current+1) << 3
+		nibble |= Bit(data, current+2) << 2
+		nibble |= Bit(data, current+3) << 1
+		nibble |= Bit(data, current+4)
+
+		current += 5
+
+		u <<= 4
+		u |= uint(nibble)
+	}
+	return u, current - bitpos, nil
+}
+
+func ReadFixedUint(data []byte, bitpos, bits uint) (u uint, err error) {
+	if err := checkBits(data, bitpos, bits); err != nil {
+		return 0, err
+	}
+
+	for i := uint(0); i < bits; i++ {
+		u <<= 1
+		u |= uint(Bit(data, bitpos+i))
+	}
+	return u, nil
+}
+
+func Bit(data []byte, n uint) uint8 {
+	nbyte := n / 8
+	nbit := 7 - n%8
+
+	return (data[nbyte] >> nbit) & 1
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 16, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Packet *Packet
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	hexstr, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hexstr = bytes.TrimSpace(hexstr)
+	if len(hexstr) == 0 {
+		return nil, errors.New("empty transmission")
+	}
+
+	data := make([]byte, hex.DecodedLen(len(hexstr)))
+	n, err := hex.Decode(data, hexstr)
+	if err != nil {
+		return nil, err
+	}
+	data = data[:n]
+
+	p, _, err := ReadPacket(data, 0, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Puzzle{Packet: p}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	return strconv.FormatUint(uint64(p.Packet.SumOfVersions()), 10), nil
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	return strconv.FormatUint(uint64(p.Packet.Evaluate()), 10), nil
+}
@@ -0,0 +1,67 @@
+package day16
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func addExamples(f *testing.F) {
+	files, _ := filepath.Glob("../days/testdata/day16/*.txt")
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		packet, err := hex.DecodeString(string(bytes.TrimSpace(data)))
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(packet)
+		f.Add(packet[:len(packet)/2])
+	}
+}
+
+func FuzzReadPacket(f *testing.F) {
+	addExamples(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p, n, err := ReadPacket(data, 0, 0, 0)
+		if (p == nil) == (err == nil) {
+			t.Fatalf("ReadPacket(%x) = %v, %v", data, p, err)
+		}
+
+		if err != nil {
+			return
+		}
+
+		if n > uint(len(data))*8 {
+			t.Errorf("ReadPacket(%x) read %d bits", data, n)
+		}
+
+		// Decoded packets must be valid for evaluation
+		p.SumOfVersions()
+		p.Evaluate()
+	})
+}
+
+func FuzzReadVarUint(f *testing.F) {
+	addExamples(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for pos := uint(0); pos < uint(len(data))*8; pos++ {
+			_, n, err := ReadVarUint(data, pos)
+			if err != nil {
+				continue
+			}
+
+			if n == 0 || n%5 != 0 || pos+n > uint(len(data))*8 {
+				t.Errorf("ReadVarUint(%x, %d) read %d bits", data, pos, n)
+			}
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package day16
+
+import (
+	"bufio"
+	"encoding/hex"
+	"math/rand"
+	"strings"
+)
+
+// bitWriter collects bits, most significant first.
+type bitWriter struct {
+	data []byte
+	n    uint
+}
+
+func (b *bitWriter) Write(v uint64, bits uint) {
+	for i := bits; i > 0; i-- {
+		b.writeBit(v >> (i - 1) & 1)
+	}
+}
+
+func (b *bitWriter) writeBit(bit uint64) {
+	if b.n%8 == 0 {
+		b.data = append(b.data, 0)
+	}
+	if bit != 0 {
+		b.data[b.n/8] |= 0x80 >> (b.n % 8)
+	}
+	b.n++
+}
+
+func (b *bitWriter) Append(o *bitWriter) {
+	for i := uint(0); i < o.n; i++ {
+		b.writeBit(uint64(Bit(o.data, i)))
+	}
+}
+
+// Encode appends the packet to b. Operators use the bit length type where the
+// length fits into its field and the packet count type otherwise.
+func (p *Packet) Encode(b *bitWriter) {
+	b.Write(uint64(p.Version), 3)
+	b.Write(uint64(p.TypeID), 3)
+
+	if p.TypeID == LiteralType {
+		var groups []uint64
+		for v := uint64(p.Value); ; v >>= 4 {
+			groups = append(groups, v&0xf)
+			if v <= 0xf {
+				break
+			}
+		}
+
+		for i := len(groups) - 1; i >= 0; i-- {
+			more := uint64(0x10)
+			if i == 0 {
+				more = 0
+			}
+			b.Write(more|groups[i], 5)
+		}
+		return
+	}
+
+	var subs bitWriter
+	for _, s := range p.Sub {
+		s.Encode(&subs)
+	}
+
+	if subs.n < 1<<BitLengthBits {
+		b.Write(uint64(BitLength), 1)
+		b.Write(uint64(subs.n), BitLengthBits)
+	} else {
+		b.Write(uint64(PacketCount), 1)
+		b.Write(uint64(len(p.Sub)), PacketCountBits)
+	}
+
+	b.Append(&subs)
+}
+
+// RandomPacket returns a packet made of size packets in total. Products only
+// get a single operand so that the value of the packet cannot overflow.
+func RandomPacket(rnd *rand.Rand, size int) *Packet {
+	p := &Packet{Header: Header{Version: uint8(rnd.Intn(8))}}
+
+	if size <= 1 {
+		p.TypeID = LiteralType
+		p.Value = uint(rnd.Intn(16))
+		if rnd.Intn(4) == 0 {
+			p.Value = uint(rnd.Int63n(1 << 20))
+		}
+		return p
+	}
+
+	var subs []int
+
+	switch {
+	case size == 2:
+		p.TypeID = [...]PacketType{SumType, ProductType, MinimumType, MaximumType}[rnd.Intn(4)]
+		subs = []int{1}
+	case rnd.Intn(3) == 0:
+		p.TypeID = GreaterType + PacketType(rnd.Intn(3))
+		n := 1 + rnd.Intn(size-2)
+		subs = []int{n, size - 1 - n}
+	default:
+		p.TypeID = [...]PacketType{SumType, MinimumType, MaximumType}[rnd.Intn(3)]
+
+		n := 1 + rnd.Intn(4)
+		if n > size-1 {
+			n = size - 1
+		}
+
+		subs = make([]int, n)
+		for i := range subs {
+			subs[i] = 1
+		}
+		for i := n; i < size-1; i++ {
+			subs[rnd.Intn(n)]++
+		}
+	}
+
+	for _, n := range subs {
+		p.Sub = append(p.Sub, RandomPacket(rnd, n))
+	}
+
+	return p
+}
+
+// Generate writes a transmission holding a random packet made of size
+// packets.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 300
+	}
+
+	var b bitWriter
+	RandomPacket(rnd, size).Encode(&b)
+
+	w.WriteString(strings.ToUpper(hex.EncodeToString(b.data)))
+	w.WriteByte('\n')
+}
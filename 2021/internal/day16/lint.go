@@ -0,0 +1,27 @@
+package day16
+
+import (
+	"regexp"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+var transmissionRx = regexp.MustCompile(`^([0-9A-F]{2})+$`)
+
+// Lint checks that the input is a single line of hexadecimal bytes.
+func Lint(input []byte) []lint.Diagnostic {
+	lines := lint.Lines(input)
+
+	diags := lint.Count(lines, 1, 1, "lines")
+
+	for _, l := range lines {
+		if len(l.Text)%2 != 0 && transmissionRx.MatchString(l.Text+"0") {
+			diags = append(diags, lint.Errorf(l.Num, len(l.Text), "odd number of hex digits, the transmission may be truncated"))
+			continue
+		}
+		diags = append(diags, lint.Pattern([]parse.Line{l}, transmissionRx, "0123456789ABCDEF", "a transmission in upper-case hex")...)
+	}
+
+	return diags
+}
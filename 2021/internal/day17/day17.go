@@ -0,0 +1,261 @@
+// Solution for https://adventofcode.com/2021/day/17
+
+package day17
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"strconv"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+	"codeberg.org/mhofmann/adventofcode/internal/geom"
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+const (
+	InputFormat = "target area: x=%d..%d, y=%d..%d"
+)
+
+type Point = geom.Point[int]
+
+// TargetArea is given in the coordinates of the puzzle, with Y growing
+// upwards.
+type TargetArea struct {
+	geom.Box[int]
+}
+
+func ReadTargetArea(r io.Reader) (*TargetArea, error) {
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) != 1 {
+		return nil, fmt.Errorf("expected a single line, got %d", len(lines))
+	}
+
+	var ta TargetArea
+	if err := lines[0].Scanf(InputFormat, &ta.Min.X, &ta.Max.X, &ta.Min.Y, &ta.Max.Y); err != nil {
+		return nil, err
+	}
+
+	if ta.Empty() {
+		return nil, fmt.Errorf("empty target area")
+	}
+
+	// The solution assumes that the probe is launched up and to the right
+	if ta.Min.X <= 0 || ta.Max.Y >= 0 {
+		return nil, fmt.Errorf("target area is not below and to the right of the launcher")
+	}
+
+	return &ta, nil
+}
+
+// step moves a probe at pos with velocity v on by one step and returns its
+// new position and velocity.
+func step(pos, v Point) (Point, Point) {
+	pos = pos.Add(v)
+	v.X -= geom.Sign(v.X)
+	v.Y--
+
+	return pos, v
+}
+
+// decided reports whether the fate of a probe is known once it reached pos,
+// and if so, whether it is in the target area.
+func (ta *TargetArea) decided(pos Point) (done, hit bool) {
+	if pos.Y < ta.Min.Y {
+		return true, false
+	}
+
+	if pos.X < ta.Min.X || pos.Y > ta.Max.Y {
+		return false, false
+	}
+
+	return true, ta.Contains(pos)
+}
+
+// CanHit reports whether a probe launched with velocity v is within the
+// target area after any step.
+func (ta *TargetArea) CanHit(v Point) bool {
+	var pos Point
+
+	for {
+		pos, v = step(pos, v)
+
+		if done, hit := ta.decided(pos); done {
+			return hit
+		}
+	}
+}
+
+func init() {
+	aoc.Register(aoc.Day{Number: 17, Parse: Parse, Lint: Lint, Gen: Generate})
+}
+
+type Puzzle struct {
+	Target *TargetArea
+
+	sink frame.Sink
+}
+
+func Parse(r io.Reader) (aoc.Puzzle, error) {
+	ta, err := ReadTargetArea(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Puzzle{Target: ta}, nil
+}
+
+func Solve(r io.Reader) (aoc.Answers, error) {
+	return aoc.Solve(r, Parse)
+}
+
+// Trajectory returns the positions of a probe launched with velocity v up to
+// the one where CanHit decides, and whether that one is in the area.
+func (ta *TargetArea) Trajectory(v Point) (path []Point, hit bool) {
+	var pos Point
+
+	for {
+		pos, v = step(pos, v)
+		path = append(path, pos)
+
+		if done, hit := ta.decided(pos); done {
+			return path, hit
+		}
+	}
+}
+
+// Trace is like CanHit, but emits a frame for each step of the probe to sink.
+// Frames show the target area as 1, the trajectory as 2 and the probe as 3,
+// with y growing upwards. They cover the launcher, the area and the whole
+// trajectory. Trace gives up with the error of ctx once it is done.
+func (ta *TargetArea) Trace(ctx context.Context, v Point, sink frame.Sink) (bool, error) {
+	path, hit := ta.Trajectory(v)
+
+	box := geom.BoundingBox(path...).Extend(Point{}).Extend(ta.Min).Extend(ta.Max)
+
+	f := &frame.Frame{
+		Grid:   grid.New[uint8](box.Width(), box.Height()),
+		Origin: geom.Pt(box.Min.X, box.Max.Y),
+		Max:    3,
+		Chars:  ".T#S",
+	}
+
+	cell := func(p Point) grid.Point {
+		return geom.Pt(p.X-box.Min.X, box.Max.Y-p.Y)
+	}
+
+	for y := ta.Min.Y; y <= ta.Max.Y; y++ {
+		for x := ta.Min.X; x <= ta.Max.X; x++ {
+			f.Grid.Set(cell(geom.Pt(x, y)), 1)
+		}
+	}
+
+	f.Grid.Set(cell(Point{}), 3)
+	sink.Emit(f)
+
+	prev := Point{}
+
+	for i, p := range path {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		f.Grid.Set(cell(prev), 2)
+		f.Grid.Set(cell(p), 3)
+
+		f.Step = i + 1
+		f.Label = fmt.Sprintf("probe at %d,%d", p.X, p.Y)
+		if i == len(path)-1 && hit {
+			f.Label += ", hit"
+		}
+		sink.Emit(f)
+
+		prev = p
+	}
+
+	return hit, nil
+}
+
+// minVelocityX returns the smallest x velocity that carries the probe as far
+// as the target area before drag stops it.
+func (ta *TargetArea) minVelocityX() int {
+	return int(math.Ceil(-0.5 + math.Sqrt(0.25+float64(ta.Min.X*2))))
+}
+
+func (ta *TargetArea) MaxHeight() int {
+	return geom.Abs((ta.Min.Y * (geom.Abs(ta.Min.Y) - 1)) / 2)
+}
+
+func (p *Puzzle) Record(sink frame.Sink) {
+	p.sink = sink
+}
+
+func (p *Puzzle) Part1() (string, error) {
+	return p.Part1Context(context.Background())
+}
+
+func (p *Puzzle) Part2() (string, error) {
+	return p.Part2Context(context.Background())
+}
+
+// Part1Context computes the answer right away, but the trace of the highest
+// shot recorded along with it can take long for targets far below.
+func (p *Puzzle) Part1Context(ctx context.Context) (string, error) {
+	if p.sink != nil {
+		// Replay the highest shot, which drops straight down into the area
+		v := geom.Pt(p.Target.minVelocityX(), -p.Target.Min.Y-1)
+		if _, err := p.Target.Trace(ctx, v, p.sink); err != nil {
+			return "", err
+		}
+	}
+
+	return strconv.Itoa(p.Target.MaxHeight()), nil
+}
+
+func (p *Puzzle) Part2Context(ctx context.Context) (string, error) {
+	ta := p.Target
+
+	vxmin := ta.minVelocityX()
+	vxmax := ta.Max.X
+	vymin := ta.Min.Y
+	vymax := ta.MaxHeight()
+
+	sum := make(chan int)
+
+	ncpu := runtime.NumCPU()
+	for cpu := 0; cpu < ncpu; cpu++ {
+		go func(offset int) {
+			var hits int
+
+			for vy := vymin + offset; vy <= vymax && ctx.Err() == nil; vy += ncpu {
+				for vx := vxmin; vx <= vxmax; vx++ {
+					if ta.CanHit(geom.Pt(vx, vy)) {
+						hits++
+					}
+				}
+			}
+
+			sum <- hits
+		}(cpu)
+	}
+
+	var hits int
+
+	for cpu := 0; cpu < ncpu; cpu++ {
+		hits += <-sum
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(hits), nil
+}
@@ -0,0 +1,26 @@
+package day17
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+)
+
+// Generate writes a target area below and to the right of the launcher whose
+// x range holds the triangular number of size. This lets a probe with that x
+// velocity drop straight down into the area, which MaxHeight relies on.
+func Generate(w *bufio.Writer, rnd *rand.Rand, size int) {
+	if size == 0 {
+		size = 17
+	}
+
+	t := size * (size + 1) / 2
+
+	var ta TargetArea
+	ta.Min.X = t - rnd.Intn(size/2+1)
+	ta.Max.X = t + rnd.Intn(size+1)
+	ta.Max.Y = -(4*size + rnd.Intn(size+1))
+	ta.Min.Y = ta.Max.Y - (2*size + rnd.Intn(size+1))
+
+	fmt.Fprintf(w, InputFormat+"\n", ta.Min.X, ta.Max.X, ta.Min.Y, ta.Max.Y)
+}
@@ -0,0 +1,29 @@
+package day17
+
+import (
+	"bytes"
+	"errors"
+
+	"codeberg.org/mhofmann/adventofcode/internal/lint"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+// Lint checks that the input describes a target area the solver can handle.
+func Lint(input []byte) []lint.Diagnostic {
+	lines := lint.Lines(input)
+
+	diags := lint.Count(lines, 1, 1, "lines")
+	if len(lines) == 0 {
+		return diags
+	}
+
+	if _, err := ReadTargetArea(bytes.NewReader([]byte(lines[0].Text))); err != nil {
+		var perr *parse.Error
+		if errors.As(err, &perr) {
+			err = perr.Err
+		}
+		diags = append(diags, lint.Errorf(lines[0].Num, 0, "%v", err))
+	}
+
+	return diags
+}
@@ -0,0 +1,61 @@
+package day17
+
+import (
+	"strconv"
+	"testing"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc/aoctest"
+)
+
+// referenceHits launches probes with every velocity that could possibly
+// reach the target and follows each until it is past the target.
+func referenceHits(ta *TargetArea) (maxHeight, hits int) {
+	limit := -ta.Min.Y
+
+	for vx := 0; vx <= ta.Max.X; vx++ {
+		for vy := ta.Min.Y; vy <= limit; vy++ {
+			var (
+				pos, v = Point{}, Point{X: vx, Y: vy}
+				top    int
+			)
+
+			for pos.X <= ta.Max.X && pos.Y >= ta.Min.Y {
+				pos.X, pos.Y = pos.X+v.X, pos.Y+v.Y
+				if v.X > 0 {
+					v.X--
+				}
+				v.Y--
+
+				if pos.Y > top {
+					top = pos.Y
+				}
+
+				if ta.Contains(pos) {
+					hits++
+					if top > maxHeight {
+						maxHeight = top
+					}
+					break
+				}
+			}
+		}
+	}
+
+	return maxHeight, hits
+}
+
+func TestTargetReference(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		p := aoctest.Generate[*Puzzle](t, 17, seed, 2+int(seed)%6)
+
+		maxHeight, hits := referenceHits(p.Target)
+
+		if got, _ := p.Part1(); got != strconv.Itoa(maxHeight) {
+			t.Errorf("seed %d: part 1 got %s, want %d", seed, got, maxHeight)
+		}
+
+		if got, _ := p.Part2(); got != strconv.Itoa(hits) {
+			t.Errorf("seed %d: part 2 got %s, want %d", seed, got, hits)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Package days registers the solutions of all days with package aoc.
+package days
+
+import (
+	_ "codeberg.org/mhofmann/adventofcode/internal/day01"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day02"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day03"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day04"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day05"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day06"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day07"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day08"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day09"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day10"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day11"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day12"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day13"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day14"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day15"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day16"
+	_ "codeberg.org/mhofmann/adventofcode/internal/day17"
+)
@@ -0,0 +1,559 @@
+package days
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"codeberg.org/mhofmann/adventofcode/internal/aoc"
+	"codeberg.org/mhofmann/adventofcode/internal/day11"
+	"codeberg.org/mhofmann/adventofcode/internal/frame"
+)
+
+// An example is a puzzle input below testdata/dayNN, stored as <name>.txt,
+// together with the expected answers in <name>.part1 and <name>.part2.
+// Either answer file may be missing if the puzzle text does not give an
+// answer for that part.
+type example struct {
+	Name  string
+	Input string
+	Want  [2]*string
+}
+
+func readExamples(day int) ([]example, error) {
+	dir := filepath.Join("testdata", fmt.Sprintf("day%02d", day))
+
+	inputs, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+
+	var examples []example
+
+	for _, input := range inputs {
+		base := strings.TrimSuffix(input, ".txt")
+		ex := example{Name: filepath.Base(base), Input: input}
+
+		for part := 1; part <= 2; part++ {
+			data, err := os.ReadFile(fmt.Sprintf("%s.part%d", base, part))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			want := strings.TrimSuffix(string(data), "\n")
+			ex.Want[part-1] = &want
+		}
+
+		examples = append(examples, ex)
+	}
+
+	return examples, nil
+}
+
+func TestExamples(t *testing.T) {
+	for _, d := range aoc.Days() {
+		d := d
+
+		t.Run(fmt.Sprintf("day%02d", d.Number), func(t *testing.T) {
+			examples, err := readExamples(d.Number)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(examples) == 0 {
+				t.Fatal("no examples in testdata")
+			}
+
+			for _, ex := range examples {
+				ex := ex
+
+				t.Run(ex.Name, func(t *testing.T) {
+					testExample(t, d, ex)
+				})
+			}
+		})
+	}
+}
+
+func testExample(t *testing.T, d aoc.Day, ex example) {
+	f, err := os.Open(ex.Input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	p, err := d.Parse(f)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	parts := [2]func() (string, error){p.Part1, p.Part2}
+
+	for i, want := range ex.Want {
+		if want == nil {
+			continue
+		}
+
+		// Answers with warnings are still compared, like the dots of day 13
+		// that form no letters
+		var warning aoc.Warning
+
+		got, err := parts[i]()
+		if err != nil && !errors.As(err, &warning) {
+			t.Errorf("part %d: %v", i+1, err)
+			continue
+		}
+
+		if got = strings.TrimSuffix(got, "\n"); got != *want {
+			t.Errorf("part %d: got %q, want %q", i+1, got, *want)
+		}
+	}
+}
+
+func TestParams(t *testing.T) {
+	tests := []struct {
+		day    int
+		part   int
+		params aoc.Params
+		want   string
+	}{
+		{3, 1, aoc.Params{"width": "4"}, "54"},
+		{3, 2, aoc.Params{"width": "8"}, "230"},
+		{6, 1, aoc.Params{"days": "18"}, "26"},
+		{6, 2, aoc.Params{"days": "0"}, "5"},
+		{11, 1, aoc.Params{"steps": "10"}, "204"},
+		{14, 2, aoc.Params{"steps": "0"}, "1"},
+		{15, 2, aoc.Params{"tiles": "1"}, "40"},
+	}
+
+	for _, test := range tests {
+		d, _ := aoc.Lookup(test.day)
+
+		examples, err := readExamples(test.day)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		input, err := os.ReadFile(examples[0].Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := d.Measure(input, aoc.Options{Part: test.part, Params: test.params})
+		if len(r.Parts) != 1 {
+			t.Fatalf("day %d: got %d results, want 1", test.day, len(r.Parts))
+		}
+
+		if res := r.Parts[0]; res.Error != "" || res.Answer != test.want {
+			t.Errorf("day %d part %d with %v: got %q (%s), want %q", test.day, test.part, test.params, res.Answer, res.Error, test.want)
+		}
+	}
+
+	d, _ := aoc.Lookup(1)
+	if r := d.Measure([]byte("1\n2\n"), aoc.Options{Params: aoc.Params{"days": "1"}}); !r.Failed() {
+		t.Error("day 1 accepted a parameter it does not have")
+	}
+}
+
+// Malformed inputs must be reported as errors rather than crash a day.
+func TestMalformedInputs(t *testing.T) {
+	inputs := []string{
+		"",
+		"\n",
+		"\n\n\n",
+		"garbage\n",
+		"-1\n",
+		"1,2,3,9\n",
+		"0,0 -> 1,3\n",
+		"0123456789\n01234\n",
+		"A-B\nstart-A\nB-end\n",
+		"1,1\n\nfold along x=0\n",
+		"8A004A80\n",
+		"C200B40A82\n",
+		"target area: x=-10..-5, y=5..10\n",
+		"ab cd | ef\n",
+		"(]\n",
+	}
+
+	for _, d := range aoc.Days() {
+		for _, input := range inputs {
+			r := d.Measure([]byte(input), aoc.Options{})
+
+			for _, res := range r.Parts {
+				if strings.HasPrefix(res.Error, "panic:") {
+					t.Errorf("day %d part %d with input %q: %s", d.Number, res.Part, input, res.Error)
+				}
+			}
+		}
+	}
+}
+
+func TestLintExamples(t *testing.T) {
+	for _, d := range aoc.Days() {
+		examples, err := readExamples(d.Number)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, ex := range examples {
+			input, err := os.ReadFile(ex.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, diag := range d.Check(input) {
+				t.Errorf("%s:%v", ex.Input, diag)
+			}
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		day   int
+		input string
+		want  []string
+	}{
+		{1, "199\r\n200\r\n", []string{"1:4: line ends with CR LF instead of LF (and 1 more lines)"}},
+		{2, "forward 5\nbackward 3\n", []string{`2:1: unexpected 'b', expected "forward|down|up <units>"`}},
+		{2, "forward 5\ndown\n", []string{`2: "down" is not "forward|down|up <units>"`}},
+		{3, "0101010101010101010101010101010101010101010101010101010101010101\n", []string{"1:64: numbers have 64 bits, at most 63 are supported"}},
+		{4, "7,4,9\n\n1 2 3 4 5\n", []string{"3: board has 1 rows instead of 5"}},
+		{5, "0,0 -> 1,3\n", []string{`1: line "0,0 -> 1,3" is neither axis-aligned nor diagonal`}},
+		{6, "3,4,9\n", []string{`1:5: unexpected '9', expected a comma-separated list of timers from 0 to 8`}},
+		{11, "5483143223\n2745854711\n", []string{"2: grid has 2 rows instead of 10"}},
+		{12, "start-A\nA-b\n", []string{"no end cave"}},
+		{13, "6,10\nfold along y=7\n", []string{"expected dots and fold instructions separated by a blank line"}},
+		{14, "NNCB\n\nCH -> B\nCH -> C\n", []string{"4:1: rule for CH already given in line 3"}},
+		{15, "116\n138\n210\n", []string{"3:3: unexpected '0' in grid"}},
+		{16, "8A004A801\n", []string{"1:9: odd number of hex digits, the transmission may be truncated"}},
+		{16, "8A004A80", []string{"1:9: missing line feed at end of input"}},
+	}
+
+	for _, test := range tests {
+		d, _ := aoc.Lookup(test.day)
+
+		var got []string
+		for _, diag := range d.Check([]byte(test.input)) {
+			got = append(got, diag.String())
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("day %d with input %q: got %q, want %q", test.day, test.input, got, test.want)
+		}
+	}
+}
+
+// Generated inputs must be deterministic, pass the linter and be solvable.
+func TestGenerate(t *testing.T) {
+	// Smaller than official sizes where solving those takes long
+	sizes := map[int]int{17: 5}
+
+	for _, d := range aoc.Days() {
+		for seed := int64(1); seed <= 3; seed++ {
+			var a, b bytes.Buffer
+
+			if err := d.Generate(&a, seed, sizes[d.Number]); err != nil {
+				t.Fatalf("day %d: %v", d.Number, err)
+			}
+			d.Generate(&b, seed, sizes[d.Number])
+
+			if !bytes.Equal(a.Bytes(), b.Bytes()) {
+				t.Errorf("day %d seed %d: generated different inputs", d.Number, seed)
+			}
+
+			for _, diag := range d.Check(a.Bytes()) {
+				t.Errorf("day %d seed %d: %v", d.Number, seed, diag)
+			}
+
+			r := d.Measure(a.Bytes(), aoc.Options{})
+			if r.Failed() {
+				t.Errorf("day %d seed %d: %+v", d.Number, seed, r)
+			}
+
+			for _, res := range r.Parts {
+				if res.Warning != "" {
+					t.Errorf("day %d seed %d part %d: %s", d.Number, seed, res.Part, res.Warning)
+				}
+			}
+		}
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	d, _ := aoc.Lookup(12)
+
+	// Far too many paths to count in part 2
+	var input bytes.Buffer
+	if err := d.Generate(&input, 3, 40); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	r := d.Measure(input.Bytes(), aoc.Options{Part: 2, Timeout: 100 * time.Millisecond})
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v to time out", elapsed)
+	}
+
+	if want := "timed out after 100ms"; r.Parts[0].Error != want {
+		t.Errorf("got error %q, want %q", r.Parts[0].Error, want)
+	}
+}
+
+// Solvers that do not watch for timeouts keep running after them, which
+// Wait must wait for.
+func TestTimeoutWait(t *testing.T) {
+	d, _ := aoc.Lookup(6)
+
+	input, err := os.ReadFile(filepath.Join("testdata", "day06", "example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var done int32
+
+	opts := aoc.Options{
+		Part:    1,
+		Timeout: time.Millisecond,
+		Params:  aoc.Params{"days": "30000000"},
+	}
+
+	start := time.Now()
+	r := d.Measure(input, opts)
+	timedOut := time.Since(start)
+
+	go func() {
+		r.Wait()
+		atomic.StoreInt32(&done, 1)
+	}()
+
+	if !r.Failed() {
+		t.Fatal("day 6 did not time out")
+	}
+
+	// The solver needs far longer than the timeout
+	time.Sleep(timedOut + 5*time.Millisecond)
+	if atomic.LoadInt32(&done) != 0 {
+		t.Error("Wait returned while the solver was still running")
+	}
+
+	r.Wait()
+}
+
+// Recording the steps of a simulation must not change its answers.
+func TestFrames(t *testing.T) {
+	for _, d := range aoc.Days() {
+		examples, err := readExamples(d.Number)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		input, err := os.ReadFile(examples[0].Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var rec frame.Recorder
+
+		plain := d.Measure(input, aoc.Options{})
+		recorded := d.Measure(input, aoc.Options{Frames: &rec})
+
+		if !reflect.DeepEqual(answers(plain), answers(recorded)) {
+			t.Errorf("day %d: got %v with frames, want %v", d.Number, answers(recorded), answers(plain))
+		}
+
+		p, err := d.Parse(bytes.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := p.(aoc.Recordable); ok && len(rec.Frames) == 0 {
+			t.Errorf("day %d: no frames recorded", d.Number)
+		}
+
+		for _, f := range rec.Frames {
+			if f.Grid == nil {
+				continue
+			}
+
+			for _, v := range f.Grid.Cells {
+				if v > f.Max {
+					t.Errorf("day %d: step %d has a cell of %d above the maximum of %d", d.Number, f.Step, v, f.Max)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	d, _ := aoc.Lookup(11)
+
+	input, err := os.ReadFile(filepath.Join("testdata", "day11", "example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anim := frame.Animation{Last: -1}
+	d.Measure(input, aoc.Options{Frames: &anim})
+
+	// Part 2 runs from the start until all octopuses flash in step 195
+	frames := anim.Frames()
+	if len(frames) != 196 {
+		t.Fatalf("got %d frames, want 196", len(frames))
+	}
+
+	for _, v := range frames[195].Grid.Cells {
+		if v != day11.Flashed {
+			t.Errorf("got a cell of %d in the last frame, want all %d", v, day11.Flashed)
+			break
+		}
+	}
+}
+
+// Repeated runs are for timing and must not record the steps again.
+func TestRepeatFrames(t *testing.T) {
+	d, _ := aoc.Lookup(11)
+
+	input, err := os.ReadFile(filepath.Join("testdata", "day11", "example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var once, repeated frame.Recorder
+	d.Measure(input, aoc.Options{Frames: &once})
+	d.Measure(input, aoc.Options{Repeat: 3, Frames: &repeated})
+
+	if len(repeated.Frames) != len(once.Frames) {
+		t.Errorf("got %d frames from 3 repetitions, want %d", len(repeated.Frames), len(once.Frames))
+	}
+}
+
+func TestRequireFrames(t *testing.T) {
+	d, _ := aoc.Lookup(1)
+
+	input, err := os.ReadFile(filepath.Join("testdata", "day01", "example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := d.Measure(input, aoc.Options{RequireFrames: true})
+	for _, res := range r.Parts {
+		if res.Error == "" || res.Answer != "" {
+			t.Errorf("part %d: got answer %q, want an error for a day without frames", res.Part, res.Answer)
+		}
+	}
+}
+
+func TestWarning(t *testing.T) {
+	d, _ := aoc.Lookup(13)
+
+	input, err := os.ReadFile(filepath.Join("testdata", "day13", "example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The dots of the example form a square instead of letters
+	r := d.Measure(input, aoc.Options{Part: 2})
+	if res := r.Parts[0]; r.Failed() || res.Warning == "" || !strings.HasPrefix(res.Answer, "#####\n") {
+		t.Errorf("got answer %q with warning %q and error %q, want the dots with a warning", res.Answer, res.Warning, res.Error)
+	}
+}
+
+func answers(r aoc.Report) []string {
+	var a []string
+	for _, res := range r.Parts {
+		a = append(a, res.Answer+res.Error)
+	}
+	return a
+}
+
+func TestExamplesRegistered(t *testing.T) {
+	dirs, err := filepath.Glob(filepath.Join("testdata", "day*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range dirs {
+		var n int
+		if _, err := fmt.Sscanf(filepath.Base(dir), "day%d", &n); err != nil {
+			t.Errorf("%s: unexpected directory name", dir)
+			continue
+		}
+
+		if _, ok := aoc.Lookup(n); !ok {
+			t.Errorf("%s: no solution registered for day %d", dir, n)
+		}
+	}
+}
+
+// benchmarkInput returns the input used to benchmark day: the real puzzle
+// input if aoc.InputDirEnv points to a directory holding it, otherwise the
+// first example.
+func benchmarkInput(day int) ([]byte, error) {
+	if dir := os.Getenv(aoc.InputDirEnv); dir != "" {
+		data, err := os.ReadFile(aoc.InputPath(dir, day))
+		if err == nil || !os.IsNotExist(err) {
+			return data, err
+		}
+	}
+
+	examples, err := readExamples(day)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(examples) == 0 {
+		return nil, fmt.Errorf("no input for day %d", day)
+	}
+
+	return os.ReadFile(examples[0].Input)
+}
+
+func BenchmarkSolvers(b *testing.B) {
+	for _, d := range aoc.Days() {
+		input, err := benchmarkInput(d.Number)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		p, err := d.Parse(bytes.NewReader(input))
+		if err != nil {
+			b.Fatalf("day %d: %v", d.Number, err)
+		}
+
+		name := fmt.Sprintf("day%02d", d.Number)
+
+		b.Run(name+"/parse", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := d.Parse(bytes.NewReader(input)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		for i, part := range [2]func() (string, error){p.Part1, p.Part2} {
+			part := part
+
+			b.Run(fmt.Sprintf("%s/part%d", name, i+1), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					var warning aoc.Warning
+					if _, err := part(); err != nil && !errors.As(err, &warning) {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
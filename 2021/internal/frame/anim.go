@@ -0,0 +1,82 @@
+package frame
+
+import (
+	"errors"
+	"fmt"
+	"image/gif"
+	"io"
+	"sync"
+	"time"
+)
+
+// Animation is a sink that keeps the frames of the last run of a simulation
+// for the steps from First to Last, or to the end if Last is negative. A run
+// starts with a frame of step 0, so when both parts of a puzzle simulate
+// from the start, only the frames of part 2 are kept.
+type Animation struct {
+	First, Last int
+
+	mu     sync.Mutex
+	frames []*Frame
+}
+
+func (a *Animation) Emit(f *Frame) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if f.Step == 0 {
+		a.frames = a.frames[:0]
+	}
+
+	if f.Step >= a.First && (a.Last < 0 || f.Step <= a.Last) {
+		a.frames = append(a.frames, f.Clone())
+	}
+}
+
+// Frames returns the frames kept so far.
+func (a *Animation) Frames() []*Frame {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.frames
+}
+
+// WriteGIF writes frames to w as an animated GIF that shows each frame for
+// delay and loops forever. The frames are drawn as described by Image.
+func WriteGIF(w io.Writer, frames []*Frame, pal Palette, scale int, delay time.Duration) error {
+	if len(frames) == 0 {
+		return errors.New("no frames to animate")
+	}
+
+	if delay < 0 {
+		return fmt.Errorf("invalid delay %v", delay)
+	}
+
+	anim := &gif.GIF{}
+
+	for _, f := range frames {
+		img, err := f.Image(pal, scale)
+		if err != nil {
+			return fmt.Errorf("step %d: %w", f.Step, err)
+		}
+
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, int(delay/(10*time.Millisecond)))
+
+		// Frames may shrink, like the paper of day 13, so each one clears
+		// the screen it was drawn on
+		anim.Disposal = append(anim.Disposal, gif.DisposalBackground)
+
+		anim.Config.Width = maxInt(anim.Config.Width, img.Rect.Dx())
+		anim.Config.Height = maxInt(anim.Config.Height, img.Rect.Dy())
+	}
+
+	return gif.EncodeAll(w, anim)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,74 @@
+package frame
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"codeberg.org/mhofmann/adventofcode/internal/term"
+)
+
+// WriteANSI draws f on a terminal in at most width columns and height rows,
+// starting at the position of the cursor. Each character shows two cells on
+// top of each other in the colours of pal, or of the frame's palette if pal
+// is nil. Frames without a grid are written as text. Cells and lines beyond
+// the limits are left out.
+func (f *Frame) WriteANSI(w io.Writer, pal Palette, width, height int) error {
+	bw := bufio.NewWriter(w)
+
+	if f.Grid == nil {
+		lines := strings.Split(strings.TrimSuffix(f.Text, "\n"), "\n")
+
+		for i, line := range lines {
+			if i == height {
+				break
+			}
+			if len(line) > width {
+				line = line[:width]
+			}
+			fmt.Fprint(bw, line, term.ClearLine, "\n")
+		}
+
+		return bw.Flush()
+	}
+
+	if pal == nil {
+		pal = f.Palette
+	}
+	if pal == nil {
+		pal = Palettes["gray"]
+	}
+
+	cols := f.Grid.Width
+	if cols > width {
+		cols = width
+	}
+
+	for y := 0; y < f.Grid.Height && y/2 < height; y += 2 {
+		top := f.Grid.Row(y)
+
+		for x := 0; x < cols; x++ {
+			r, g, b := rgb(pal.Color(top[x], f.Max))
+			fmt.Fprintf(bw, "\x1b[38;2;%d;%d;%dm", r, g, b)
+
+			// The last row of a grid with an odd height has nothing below
+			if y+1 < f.Grid.Height {
+				r, g, b = rgb(pal.Color(f.Grid.Row(y + 1)[x], f.Max))
+				fmt.Fprintf(bw, "\x1b[48;2;%d;%d;%dm", r, g, b)
+			}
+
+			bw.WriteString("▀")
+		}
+
+		fmt.Fprint(bw, term.Reset, term.ClearLine, "\n")
+	}
+
+	return bw.Flush()
+}
+
+func rgb(c color.Color) (r, g, b uint8) {
+	r32, g32, b32, _ := c.RGBA()
+	return uint8(r32 >> 8), uint8(g32 >> 8), uint8(b32 >> 8)
+}
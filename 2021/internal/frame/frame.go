@@ -0,0 +1,170 @@
+// Package frame records the intermediate states of simulations, so that they
+// can be rendered as text, images or animations.
+//
+// Simulations only build frames if they were given a Sink, so recording
+// costs nothing when it is disabled.
+package frame
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+)
+
+// Frame is one state of a simulation. Most frames show a grid of small
+// values, whose meaning depends on the simulation; frames of simulations
+// without a natural grid hold text instead.
+type Frame struct {
+	Step  int    // Counting from 0 for the initial state
+	Label string // What happened in this step, may be empty
+
+	Grid    *grid.Grid[uint8]
+	Origin  grid.Point // Puzzle coordinates of the top left cell
+	Max     uint8      // Largest value a cell can take, for scaling colours
+	Chars   string     // Characters for the cell values in text output, optional
+	Palette Palette    // Colours for the cell values in images, optional
+
+	Text string
+}
+
+// Clone returns a copy of f that does not share its grid.
+func (f *Frame) Clone() *Frame {
+	c := *f
+	if f.Grid != nil {
+		c.Grid = f.Grid.Clone()
+	}
+	return &c
+}
+
+// Char returns the character to print for cell value v.
+func (f *Frame) Char(v uint8) byte {
+	switch {
+	case int(v) < len(f.Chars):
+		return f.Chars[v]
+	case v < 10:
+		return '0' + v
+	default:
+		return '+'
+	}
+}
+
+// Sink receives the frames of a simulation. The frame and its grid are only
+// valid during the call, as simulations reuse them; sinks that keep frames
+// must clone them.
+type Sink interface {
+	Emit(f *Frame)
+}
+
+// Recorder is a sink that keeps all frames in memory.
+type Recorder struct {
+	mu     sync.Mutex
+	Frames []*Frame
+}
+
+func (r *Recorder) Emit(f *Frame) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Frames = append(r.Frames, f.Clone())
+}
+
+// Last is a sink that keeps a copy of the most recent frame.
+type Last struct {
+	mu    sync.Mutex
+	frame *Frame
+}
+
+func (l *Last) Emit(f *Frame) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Reuse the grid of the previous copy, as simulations emit many frames
+	// of the same size
+	if prev := l.frame; prev != nil && prev.Grid != nil && f.Grid != nil &&
+		prev.Grid.Width == f.Grid.Width && prev.Grid.Height == f.Grid.Height {
+		g := prev.Grid
+		copy(g.Cells, f.Grid.Cells)
+		*prev = *f
+		prev.Grid = g
+		return
+	}
+
+	l.frame = f.Clone()
+}
+
+// Frame returns the most recent frame, or nil if there was none.
+func (l *Last) Frame() *Frame {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.frame
+}
+
+// Tee returns a sink that passes each frame on to all sinks.
+func Tee(sinks ...Sink) Sink {
+	return tee(sinks)
+}
+
+type tee []Sink
+
+func (t tee) Emit(f *Frame) {
+	for _, s := range t {
+		s.Emit(f)
+	}
+}
+
+// Text is a sink that writes each frame as a header line with its step and
+// label, followed by the grid or text and a blank line. It is safe for use
+// by concurrent simulations.
+type Text struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+func NewText(w io.Writer) *Text {
+	return &Text{w: w}
+}
+
+func (t *Text) Emit(f *Frame) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "step %d", f.Step)
+	if f.Label != "" {
+		b.WriteString(": " + f.Label)
+	}
+	b.WriteByte('\n')
+
+	if f.Grid != nil {
+		for y := 0; y < f.Grid.Height; y++ {
+			for _, v := range f.Grid.Row(y) {
+				b.WriteByte(f.Char(v))
+			}
+			b.WriteByte('\n')
+		}
+	}
+
+	if f.Text != "" {
+		b.WriteString(strings.TrimSuffix(f.Text, "\n") + "\n")
+	}
+
+	b.WriteByte('\n')
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err == nil {
+		_, t.err = io.WriteString(t.w, b.String())
+	}
+}
+
+// Err returns the first error that occurred while writing.
+func (t *Text) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.err
+}
@@ -0,0 +1,301 @@
+package frame
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+)
+
+func TestText(t *testing.T) {
+	var sb strings.Builder
+	sink := NewText(&sb)
+
+	g := grid.New[uint8](3, 2)
+	g.Cells = []uint8{0, 1, 0, 1, 12, 1}
+
+	sink.Emit(&Frame{Grid: g, Chars: ".#"})
+	sink.Emit(&Frame{Step: 1, Label: "fold", Grid: g})
+	sink.Emit(&Frame{Step: 2, Text: "B 2\nN 1\n"})
+
+	want := "step 0\n.#.\n#+#\n\nstep 1: fold\n010\n1+1\n\nstep 2\nB 2\nN 1\n\n"
+	if got := sb.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if err := sink.Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	var r Recorder
+
+	f := &Frame{Grid: grid.New[uint8](2, 2)}
+
+	for i := 0; i < 3; i++ {
+		f.Step = i
+		f.Grid.Cells[0] = uint8(i)
+		r.Emit(f)
+	}
+
+	if len(r.Frames) != 3 {
+		t.Fatalf("got %d frames, want 3", len(r.Frames))
+	}
+
+	for i, f := range r.Frames {
+		if f.Step != i || f.Grid.Cells[0] != uint8(i) {
+			t.Errorf("frame %d: got step %d and cell %d, the frame was not cloned", i, f.Step, f.Grid.Cells[0])
+		}
+	}
+}
+
+func TestLast(t *testing.T) {
+	var l Last
+
+	if l.Frame() != nil {
+		t.Error("got a frame before any was emitted")
+	}
+
+	f := &Frame{Grid: grid.New[uint8](2, 2)}
+
+	for i := 0; i < 3; i++ {
+		f.Step = i
+		f.Grid.Cells[0] = uint8(i)
+		l.Emit(f)
+	}
+	f.Grid.Cells[0] = 7
+
+	if got := l.Frame(); got.Step != 2 || got.Grid.Cells[0] != 2 {
+		t.Errorf("got step %d and cell %d, want step 2 and cell 2", got.Step, got.Grid.Cells[0])
+	}
+
+	l.Emit(&Frame{Step: 3, Grid: grid.New[uint8](3, 1)})
+
+	if got := l.Frame(); got.Step != 3 || got.Grid.Width != 3 {
+		t.Errorf("got step %d and width %d, want step 3 and width 3", got.Step, got.Grid.Width)
+	}
+}
+
+func TestPalette(t *testing.T) {
+	p := Gradient(5, color.RGBA{0, 0, 0, 0xff}, color.RGBA{200, 100, 0, 0xff})
+
+	if got, want := p[2], (color.RGBA{100, 50, 0, 0xff}); got != want {
+		t.Errorf("got middle colour %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		v, max uint8
+		want   int
+	}{
+		{0, 4, 0},
+		{1, 4, 1},
+		{4, 4, 4},
+		{9, 4, 4},
+		{1, 2, 2},
+		{5, 255, 0},
+		{0, 0, 0},
+	}
+
+	for _, test := range tests {
+		if got := p.Color(test.v, test.max); got != p[test.want] {
+			t.Errorf("Color(%d, %d) = %v, want %v", test.v, test.max, got, p[test.want])
+		}
+	}
+}
+
+func TestParsePalette(t *testing.T) {
+	p, err := ParsePalette("#000000,#ff8000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Palette{color.RGBA{0, 0, 0, 0xff}, color.RGBA{0xff, 0x80, 0, 0xff}}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("got %v, want %v", p, want)
+	}
+
+	if p, err := ParsePalette("paper"); err != nil || len(p) != 2 {
+		t.Errorf("got %v, %v for the paper palette", p, err)
+	}
+
+	for _, s := range []string{"", "rainbow", "#000000,", "#12345", "#00000g"} {
+		if _, err := ParsePalette(s); err == nil {
+			t.Errorf("ParsePalette(%q): got no error", s)
+		}
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	g := grid.New[uint8](3, 2)
+	g.Cells = []uint8{0, 1, 0, 1, 1, 0}
+
+	var buf bytes.Buffer
+
+	f := &Frame{Grid: g, Max: 1, Palette: Palettes["paper"]}
+	if err := f.WritePNG(&buf, nil, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := img.Bounds().Size(); got != image.Pt(6, 4) {
+		t.Fatalf("got an image of %v, want 6x4", got)
+	}
+
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 6; x++ {
+			want := Palettes["paper"][g.At(grid.Point{X: x / 2, Y: y / 2})]
+			if r, _, _, _ := img.At(x, y).RGBA(); r>>8 != uint32(want.(color.RGBA).R) {
+				t.Errorf("pixel %d,%d: got %v, want %v", x, y, img.At(x, y), want)
+			}
+		}
+	}
+
+	if err := (&Frame{Text: "N 1"}).WritePNG(&buf, nil, 1); err == nil {
+		t.Error("got no error for a frame without a grid")
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	a := Animation{First: 1, Last: 2}
+
+	f := &Frame{Grid: grid.New[uint8](1, 1)}
+
+	// Two runs of a simulation, of which only the second one is kept
+	for _, steps := range []int{2, 4} {
+		for i := 0; i <= steps; i++ {
+			f.Step = i
+			f.Grid.Cells[0] = uint8(10*steps + i)
+			a.Emit(f)
+		}
+	}
+
+	var got []uint8
+	for _, f := range a.Frames() {
+		got = append(got, f.Grid.Cells[0])
+	}
+
+	if want := []uint8{41, 42}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got frames %v, want %v", got, want)
+	}
+}
+
+func TestWriteGIF(t *testing.T) {
+	frames := []*Frame{
+		{Grid: grid.New[uint8](3, 2), Max: 1},
+		{Step: 1, Grid: grid.New[uint8](2, 3), Max: 1},
+	}
+
+	var buf bytes.Buffer
+
+	if err := WriteGIF(&buf, frames, Palettes["paper"], 2, 250*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(g.Image) != 2 || g.Delay[0] != 25 {
+		t.Errorf("got %d images with a delay of %d, want 2 with 25", len(g.Image), g.Delay[0])
+	}
+
+	if g.Config.Width != 6 || g.Config.Height != 6 {
+		t.Errorf("got a screen of %dx%d, want 6x6", g.Config.Width, g.Config.Height)
+	}
+
+	if err := WriteGIF(&buf, nil, nil, 1, 0); err == nil {
+		t.Error("got no error without frames")
+	}
+}
+
+func TestWriteANSI(t *testing.T) {
+	g := grid.New[uint8](3, 3)
+	g.Cells = []uint8{1, 0, 0, 0, 1, 0, 0, 0, 1}
+
+	var sb strings.Builder
+
+	f := &Frame{Grid: g, Max: 1, Palette: Palettes["paper"]}
+	if err := f.WriteANSI(&sb, nil, 2, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		black = "\x1b[38;2;0;0;0m"
+		white = "\x1b[38;2;255;255;255m"
+		bgB   = "\x1b[48;2;0;0;0m"
+		bgW   = "\x1b[48;2;255;255;255m"
+		end   = "\x1b[0m\x1b[K\n"
+	)
+
+	// Two columns fit, and the third row has no row below it
+	want := black + bgW + "▀" + white + bgB + "▀" + end + white + "▀" + white + "▀" + end
+	if got := sb.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	sb.Reset()
+	if err := (&Frame{Text: "B 2\nN 1\n"}).WriteANSI(&sb, nil, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := sb.String(), "B\x1b[K\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	var frames []*Frame
+	for i := 0; i < 5; i++ {
+		frames = append(frames, &Frame{Step: i, Text: "x"})
+	}
+
+	p := &Player{
+		Frames: frames,
+		Delay:  MinDelay,
+		Size:   func() (int, int) { return 80, 24 },
+	}
+
+	// Without keys, all frames are shown once
+	var sb strings.Builder
+	if err := p.Play(&sb, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(sb.String(), "step "); n != 5 {
+		t.Errorf("drew %d frames, want 5", n)
+	}
+
+	// Pausing at the second frame, stepping around and quitting
+	p = &Player{Frames: frames, Delay: MaxDelay, Size: p.Size}
+
+	keys := make(chan byte, 10)
+	for _, k := range []byte{' ', 'n', 'n', 'b', '+', '+', 'q', 'n'} {
+		keys <- k
+	}
+
+	sb.Reset()
+	if err := p.Play(&sb, keys); err != nil {
+		t.Fatal(err)
+	}
+
+	if p.index != 1 || !p.paused || p.Delay != MaxDelay/4 {
+		t.Errorf("got frame %d, paused %v, delay %v, want frame 1, paused, delay %v", p.index, p.paused, p.Delay, MaxDelay/4)
+	}
+
+	if len(keys) != 1 {
+		t.Errorf("%d keys left, want 1 after q", len(keys))
+	}
+}
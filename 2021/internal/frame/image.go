@@ -0,0 +1,169 @@
+package frame
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Palette holds the colours of an image. Cell values from 0 to the Max of a
+// frame are spread evenly over them, so a palette with Max+1 colours gives
+// each value a colour of its own.
+type Palette []color.Color
+
+// index returns the position of the colour for cell value v.
+func (p Palette) index(v, max uint8) uint8 {
+	switch {
+	case max == 0 || len(p) < 2:
+		return 0
+	case v >= max:
+		return uint8(len(p) - 1)
+	default:
+		return uint8(int(v) * (len(p) - 1) / int(max))
+	}
+}
+
+// Color returns the colour for cell value v in a frame whose largest value
+// is max.
+func (p Palette) Color(v, max uint8) color.Color {
+	return p[p.index(v, max)]
+}
+
+// Gradient returns a palette of n colours that blend from each stop into the
+// next.
+func Gradient(n int, stops ...color.RGBA) Palette {
+	p := make(Palette, n)
+
+	for i := range p {
+		pos := float64(i) / float64(n-1) * float64(len(stops)-1)
+		j := int(pos)
+		if j >= len(stops)-1 {
+			p[i] = stops[len(stops)-1]
+			continue
+		}
+
+		t := pos - float64(j)
+		mix := func(a, b uint8) uint8 { return uint8(float64(a) + t*(float64(b)-float64(a)) + 0.5) }
+
+		a, b := stops[j], stops[j+1]
+		p[i] = color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 0xff}
+	}
+
+	return p
+}
+
+var (
+	black  = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	white  = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	red    = color.RGBA{0xe0, 0x20, 0x20, 0xff}
+	yellow = color.RGBA{0xff, 0xe0, 0x40, 0xff}
+	blue   = color.RGBA{0x20, 0x40, 0xc0, 0xff}
+)
+
+// Palettes are the palettes available by name.
+var Palettes = map[string]Palette{
+	"gray":  Gradient(256, black, white),
+	"heat":  Gradient(256, black, blue, red, yellow, white),
+	"paper": {white, black},
+
+	// Unvisited, wall and 15 colours to tell neighbouring regions apart
+	"regions": {
+		black, color.RGBA{0x40, 0x40, 0x40, 0xff},
+		color.RGBA{0xe6, 0x19, 0x4b, 0xff}, color.RGBA{0x3c, 0xb4, 0x4b, 0xff},
+		color.RGBA{0xff, 0xe1, 0x19, 0xff}, color.RGBA{0x43, 0x63, 0xd8, 0xff},
+		color.RGBA{0xf5, 0x82, 0x31, 0xff}, color.RGBA{0x91, 0x1e, 0xb4, 0xff},
+		color.RGBA{0x42, 0xd4, 0xf4, 0xff}, color.RGBA{0xf0, 0x32, 0xe6, 0xff},
+		color.RGBA{0xbf, 0xef, 0x45, 0xff}, color.RGBA{0xfa, 0xbe, 0xd4, 0xff},
+		color.RGBA{0x46, 0x99, 0x90, 0xff}, color.RGBA{0xdc, 0xbe, 0xff, 0xff},
+		color.RGBA{0x9a, 0x63, 0x24, 0xff}, color.RGBA{0xff, 0xfa, 0xc8, 0xff},
+		color.RGBA{0x80, 0x00, 0x00, 0xff},
+	},
+
+	// Ten shades for the values 0 to 9 and red for marked cells
+	"marked": append(Gradient(10, black, white), red),
+
+	// Energy levels 0 to 9 in rising brightness and a glow for flashes
+	"energy": append(Gradient(10, black, color.RGBA{0x30, 0x60, 0xa0, 0xff}, color.RGBA{0x80, 0xc0, 0xff, 0xff}), yellow),
+}
+
+// ParsePalette returns the palette with the given name, or the palette of
+// comma-separated colours given in hex like "#000000,#ff8000".
+func ParsePalette(s string) (Palette, error) {
+	if p, ok := Palettes[s]; ok {
+		return p, nil
+	}
+
+	if !strings.HasPrefix(s, "#") {
+		names := make([]string, 0, len(Palettes))
+		for name := range Palettes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("unknown palette %q, known are: %s", s, strings.Join(names, ", "))
+	}
+
+	var p Palette
+
+	for _, hex := range strings.Split(s, ",") {
+		rgb, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+		if err != nil || len(hex) != 7 || hex[0] != '#' {
+			return nil, fmt.Errorf("invalid colour %q, expected #rrggbb", hex)
+		}
+		p = append(p, color.RGBA{uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb), 0xff})
+	}
+
+	if len(p) > 256 {
+		return nil, errors.New("palette has more than 256 colours")
+	}
+
+	return p, nil
+}
+
+// Image renders the grid of f with each cell as a square of scale×scale
+// pixels, in the colours of pal, or of the frame's palette if pal is nil.
+func (f *Frame) Image(pal Palette, scale int) (*image.Paletted, error) {
+	if f.Grid == nil {
+		return nil, errors.New("frame has no grid")
+	}
+
+	if scale < 1 {
+		return nil, fmt.Errorf("invalid scale %d", scale)
+	}
+
+	if pal == nil {
+		pal = f.Palette
+	}
+	if pal == nil {
+		pal = Palettes["gray"]
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, f.Grid.Width*scale, f.Grid.Height*scale), color.Palette(pal))
+
+	for y := 0; y < img.Rect.Dy(); y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+img.Rect.Dx()]
+		cells := f.Grid.Row(y / scale)
+
+		for x := range row {
+			row[x] = pal.index(cells[x/scale], f.Max)
+		}
+	}
+
+	return img, nil
+}
+
+// WritePNG writes the grid of f to w as a PNG image, as described by Image.
+func (f *Frame) WritePNG(w io.Writer, pal Palette, scale int) error {
+	img, err := f.Image(pal, scale)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
@@ -0,0 +1,154 @@
+package frame
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"codeberg.org/mhofmann/adventofcode/internal/term"
+)
+
+// Limits of the delay between frames when changing the speed of a Player.
+const (
+	MinDelay = 10 * time.Millisecond
+	MaxDelay = 5 * time.Second
+)
+
+// PlayerKeys describes the keys a Player understands.
+const PlayerKeys = "space pause, n/b or ←/→ step, +/- speed, q quit"
+
+// Player shows frames on a terminal one after the other.
+type Player struct {
+	Frames  []*Frame
+	Palette Palette       // Optional, see WriteANSI
+	Delay   time.Duration // Between two frames
+
+	// Size returns the number of columns and rows of the terminal
+	Size func() (width, height int)
+
+	index  int
+	paused bool
+}
+
+// Play draws the frames on w, which must be a terminal showing nothing else,
+// and follows the keys pressed. Without keys, that is if keys is nil, Play
+// returns after the last frame. Otherwise it waits on the last frame until q
+// is pressed or keys is closed.
+func (p *Player) Play(w io.Writer, keys <-chan byte) error {
+	if len(p.Frames) == 0 {
+		return nil
+	}
+
+	for {
+		last := p.index == len(p.Frames)-1
+		if last && keys != nil {
+			p.paused = true
+		}
+
+		if err := p.draw(w, keys != nil); err != nil {
+			return err
+		}
+
+		var tick <-chan time.Time
+		switch {
+		case !p.paused && !last:
+			tick = time.After(p.Delay)
+		case keys == nil:
+			return nil
+		}
+
+		select {
+		case <-tick:
+			p.index++
+
+		case k, ok := <-keys:
+			if !ok {
+				// Nobody can press keys anymore, so finish on our own
+				keys = nil
+				p.paused = false
+				continue
+			}
+
+			if quit := p.press(k); quit {
+				return nil
+			}
+		}
+	}
+}
+
+// press handles a key and reports whether it asks to quit.
+func (p *Player) press(k byte) bool {
+	switch k {
+	case 'q', 'Q', 3: // Ctrl-C
+		return true
+
+	case ' ':
+		p.paused = !p.paused
+
+		// Start over when resuming at the end
+		if !p.paused && p.index == len(p.Frames)-1 {
+			p.index = 0
+		}
+
+	// The arrow keys send ESC [ C and ESC [ D, of which only the last
+	// letter matters
+	case 'n', 'l', 'C':
+		p.paused = true
+		if p.index < len(p.Frames)-1 {
+			p.index++
+		}
+
+	case 'b', 'h', 'D':
+		p.paused = true
+		if p.index > 0 {
+			p.index--
+		}
+
+	case '+', '=':
+		if p.Delay /= 2; p.Delay < MinDelay {
+			p.Delay = MinDelay
+		}
+
+	case '-':
+		if p.Delay *= 2; p.Delay > MaxDelay {
+			p.Delay = MaxDelay
+		}
+	}
+
+	return false
+}
+
+// draw shows the current frame followed by a status line.
+func (p *Player) draw(w io.Writer, interactive bool) error {
+	width, height := p.Size()
+	f := p.Frames[p.index]
+
+	if _, err := io.WriteString(w, term.Home); err != nil {
+		return err
+	}
+
+	// Leave a row for the status line
+	if err := f.WriteANSI(w, p.Palette, width, height-1); err != nil {
+		return err
+	}
+
+	status := fmt.Sprintf("step %d (%d/%d)", f.Step, p.index+1, len(p.Frames))
+	if f.Label != "" {
+		status += ": " + f.Label
+	}
+
+	if interactive {
+		state := fmt.Sprintf("every %v", p.Delay)
+		if p.paused {
+			state = "paused"
+		}
+		status += fmt.Sprintf(" | %s | %s", state, PlayerKeys)
+	}
+
+	if r := []rune(status); len(r) > width {
+		status = string(r[:width])
+	}
+
+	_, err := fmt.Fprint(w, status, term.ClearLine, term.ClearBelow)
+	return err
+}
@@ -0,0 +1,65 @@
+package geom
+
+// Box is an axis-aligned rectangle that includes both Min and Max. It is
+// empty if Min lies to the right of or below Max.
+type Box[T Signed] struct {
+	Min, Max Point[T]
+}
+
+// BoundingBox returns the smallest box that holds all points, or an empty
+// box if there are none.
+func BoundingBox[T Signed](points ...Point[T]) Box[T] {
+	b := Box[T]{Min: Point[T]{1, 1}}
+
+	for _, p := range points {
+		b = b.Extend(p)
+	}
+
+	return b
+}
+
+func (b Box[T]) Empty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y
+}
+
+// Extend returns the smallest box that holds b and p.
+func (b Box[T]) Extend(p Point[T]) Box[T] {
+	if b.Empty() {
+		return Box[T]{p, p}
+	}
+
+	if p.X < b.Min.X {
+		b.Min.X = p.X
+	}
+	if p.Y < b.Min.Y {
+		b.Min.Y = p.Y
+	}
+	if p.X > b.Max.X {
+		b.Max.X = p.X
+	}
+	if p.Y > b.Max.Y {
+		b.Max.Y = p.Y
+	}
+
+	return b
+}
+
+func (b Box[T]) Contains(p Point[T]) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+// Width returns the number of columns in b.
+func (b Box[T]) Width() T {
+	if b.Empty() {
+		return 0
+	}
+	return b.Max.X - b.Min.X + 1
+}
+
+// Height returns the number of rows in b.
+func (b Box[T]) Height() T {
+	if b.Empty() {
+		return 0
+	}
+	return b.Max.Y - b.Min.Y + 1
+}
@@ -0,0 +1,138 @@
+// Package geom implements points, directions and bounding boxes on a plane
+// with integer coordinates. Y grows downwards, as in the puzzle texts.
+package geom
+
+// Signed is the set of integer types coordinates can have.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+func Abs[T Signed](n T) T {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of n.
+func Sign[T Signed](n T) T {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Point is a position on the plane, or the vector between two positions.
+type Point[T Signed] struct {
+	X, Y T
+}
+
+func Pt[T Signed](x, y T) Point[T] {
+	return Point[T]{x, y}
+}
+
+func (p Point[T]) Add(q Point[T]) Point[T] {
+	return Point[T]{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point[T]) Sub(q Point[T]) Point[T] {
+	return Point[T]{p.X - q.X, p.Y - q.Y}
+}
+
+func (p Point[T]) Mul(k T) Point[T] {
+	return Point[T]{p.X * k, p.Y * k}
+}
+
+func (p Point[T]) Neg() Point[T] {
+	return Point[T]{-p.X, -p.Y}
+}
+
+// Sign returns p with both coordinates replaced by their sign, which is the
+// step from the origin towards p along a horizontal, vertical or diagonal line.
+func (p Point[T]) Sign() Point[T] {
+	return Point[T]{Sign(p.X), Sign(p.Y)}
+}
+
+// Manhattan returns the distance between p and q moving only horizontally
+// and vertically.
+func (p Point[T]) Manhattan(q Point[T]) T {
+	return Abs(p.X-q.X) + Abs(p.Y-q.Y)
+}
+
+// Chebyshev returns the distance between p and q moving diagonally as well.
+func (p Point[T]) Chebyshev(q Point[T]) T {
+	dx, dy := Abs(p.X-q.X), Abs(p.Y-q.Y)
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// RotateRight rotates p clockwise by 90 degrees around the origin.
+func (p Point[T]) RotateRight() Point[T] {
+	return Point[T]{-p.Y, p.X}
+}
+
+// RotateLeft rotates p counterclockwise by 90 degrees around the origin.
+func (p Point[T]) RotateLeft() Point[T] {
+	return Point[T]{p.Y, -p.X}
+}
+
+// Move returns the neighbor of p in direction d.
+func (p Point[T]) Move(d Dir) Point[T] {
+	return p.Add(Step[T](d))
+}
+
+// Dir is one of the eight directions to a neighboring point.
+type Dir uint8
+
+const (
+	Up Dir = iota
+	UpRight
+	Right
+	DownRight
+	Down
+	DownLeft
+	Left
+	UpLeft
+)
+
+var (
+	// Dirs4 are the directions to the orthogonal neighbors of a point.
+	Dirs4 = []Dir{Up, Right, Down, Left}
+
+	// Dirs8 are the directions to all neighbors of a point.
+	Dirs8 = []Dir{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+)
+
+var steps = [...][2]int8{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+
+// Step returns the vector pointing one step in direction d.
+func Step[T Signed](d Dir) Point[T] {
+	s := steps[d%8]
+	return Point[T]{T(s[0]), T(s[1])}
+}
+
+// TurnRight returns the direction after turning clockwise by 90 degrees.
+func (d Dir) TurnRight() Dir {
+	return (d + 2) % 8
+}
+
+// TurnLeft returns the direction after turning counterclockwise by 90
+// degrees.
+func (d Dir) TurnLeft() Dir {
+	return (d + 6) % 8
+}
+
+func (d Dir) Opposite() Dir {
+	return (d + 4) % 8
+}
+
+func (d Dir) String() string {
+	names := [...]string{"up", "up right", "right", "down right", "down", "down left", "left", "up left"}
+	return names[d%8]
+}
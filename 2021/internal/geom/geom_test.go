@@ -0,0 +1,74 @@
+package geom
+
+import "testing"
+
+func TestArithmetic(t *testing.T) {
+	p, q := Pt(3, -2), Pt(-1, 4)
+
+	tests := []struct {
+		name      string
+		got, want Point[int]
+	}{
+		{"Add", p.Add(q), Pt(2, 2)},
+		{"Sub", p.Sub(q), Pt(4, -6)},
+		{"Mul", p.Mul(-2), Pt(-6, 4)},
+		{"Neg", p.Neg(), Pt(-3, 2)},
+		{"Sign", p.Sign(), Pt(1, -1)},
+		{"RotateRight", p.RotateRight(), Pt(2, 3)},
+		{"RotateLeft", p.RotateLeft(), Pt(-2, -3)},
+	}
+
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, test.got, test.want)
+		}
+	}
+
+	if d := p.Manhattan(q); d != 10 {
+		t.Errorf("Manhattan: got %d, want 10", d)
+	}
+
+	if d := p.Chebyshev(q); d != 6 {
+		t.Errorf("Chebyshev: got %d, want 6", d)
+	}
+}
+
+func TestDirections(t *testing.T) {
+	for _, d := range Dirs8 {
+		if got, want := Step[int](d.TurnRight()), Step[int](d).RotateRight(); d%2 == 0 && got != want {
+			t.Errorf("%v: turning right steps %v, rotating the step gives %v", d, got, want)
+		}
+
+		if d.TurnLeft().TurnRight() != d {
+			t.Errorf("%v: turning left and right does not return to it", d)
+		}
+
+		if p := Pt[int8](0, 0).Move(d).Move(d.Opposite()); p != (Point[int8]{}) {
+			t.Errorf("%v: moving back and forth ends at %v", d, p)
+		}
+	}
+
+	if p := Pt(5, 5).Move(Up); p != Pt(5, 4) {
+		t.Errorf("moving up from 5,5 ends at %v", p)
+	}
+}
+
+func TestBox(t *testing.T) {
+	b := BoundingBox[int]()
+	if !b.Empty() || b.Width() != 0 || b.Height() != 0 {
+		t.Errorf("bounding box of no points is %+v", b)
+	}
+
+	b = BoundingBox(Pt(2, -3), Pt(-1, 0), Pt(0, 1))
+	if want := (Box[int]{Pt(-1, -3), Pt(2, 1)}); b != want {
+		t.Errorf("got bounding box %+v, want %+v", b, want)
+	}
+
+	if b.Width() != 4 || b.Height() != 5 {
+		t.Errorf("got size %dx%d, want 4x5", b.Width(), b.Height())
+	}
+
+	if !b.Contains(Pt(2, 1)) || b.Contains(Pt(3, 0)) {
+		t.Error("Contains does not include exactly the corners")
+	}
+}
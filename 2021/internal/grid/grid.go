@@ -0,0 +1,218 @@
+// Package grid implements rectangular grids of cells stored in row-major
+// order, as they appear in many puzzle inputs.
+package grid
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"codeberg.org/mhofmann/adventofcode/internal/geom"
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+// Point is the position of a cell, with X growing to the right and Y growing
+// downwards.
+type Point = geom.Point[int]
+
+type Grid[T any] struct {
+	Width, Height int
+	Cells         []T
+}
+
+// New returns a grid of the given size with all cells set to the zero value.
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{Width: width, Height: height, Cells: make([]T, width*height)}
+}
+
+func (g *Grid[T]) Clone() *Grid[T] {
+	c := *g
+	c.Cells = make([]T, len(g.Cells))
+	copy(c.Cells, g.Cells)
+	return &c
+}
+
+func (g *Grid[T]) Contains(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.Width && p.Y < g.Height
+}
+
+// Index returns the index of p in Cells. It does not check if p lies within
+// the grid.
+func (g *Grid[T]) Index(p Point) int {
+	return p.Y*g.Width + p.X
+}
+
+// Point returns the position of the cell at index i in Cells.
+func (g *Grid[T]) Point(i int) Point {
+	return Point{X: i % g.Width, Y: i / g.Width}
+}
+
+// At returns the cell at p, which must lie within the grid.
+func (g *Grid[T]) At(p Point) T {
+	return g.Cells[g.Index(p)]
+}
+
+// Get returns the cell at p and whether p lies within the grid.
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.Contains(p) {
+		var zero T
+		return zero, false
+	}
+	return g.Cells[g.Index(p)], true
+}
+
+// Set changes the cell at p and reports whether p lies within the grid.
+func (g *Grid[T]) Set(p Point, v T) bool {
+	if !g.Contains(p) {
+		return false
+	}
+	g.Cells[g.Index(p)] = v
+	return true
+}
+
+// Ref returns a pointer to the cell at p, or nil if p lies outside the grid.
+func (g *Grid[T]) Ref(p Point) *T {
+	if !g.Contains(p) {
+		return nil
+	}
+	return &g.Cells[g.Index(p)]
+}
+
+func (g *Grid[T]) neighbors(p Point, dirs []geom.Dir) []Point {
+	n := make([]Point, 0, len(dirs))
+
+	for _, d := range dirs {
+		if q := p.Move(d); g.Contains(q) {
+			n = append(n, q)
+		}
+	}
+
+	return n
+}
+
+// Neighbors4 returns the orthogonal neighbors of p within the grid.
+func (g *Grid[T]) Neighbors4(p Point) []Point {
+	return g.neighbors(p, geom.Dirs4)
+}
+
+// Neighbors8 returns the orthogonal and diagonal neighbors of p within the
+// grid.
+func (g *Grid[T]) Neighbors8(p Point) []Point {
+	return g.neighbors(p, geom.Dirs8)
+}
+
+// Row returns the cells of row y. The result shares its storage with the
+// grid.
+func (g *Grid[T]) Row(y int) []T {
+	return g.Cells[y*g.Width : (y+1)*g.Width]
+}
+
+// Column returns a copy of the cells in column x.
+func (g *Grid[T]) Column(x int) []T {
+	col := make([]T, g.Height)
+	for y := range col {
+		col[y] = g.Cells[y*g.Width+x]
+	}
+	return col
+}
+
+// Transpose returns a new grid with rows and columns swapped.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	t := New[T](g.Height, g.Width)
+
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			t.Cells[x*t.Width+y] = g.Cells[y*g.Width+x]
+		}
+	}
+
+	return t
+}
+
+// Find returns the first cell in row-major order for which match returns
+// true.
+func (g *Grid[T]) Find(match func(T) bool) (Point, bool) {
+	for i, c := range g.Cells {
+		if match(c) {
+			return g.Point(i), true
+		}
+	}
+	return Point{}, false
+}
+
+// Count returns the number of cells for which match returns true.
+func (g *Grid[T]) Count(match func(T) bool) (n int) {
+	for _, c := range g.Cells {
+		if match(c) {
+			n++
+		}
+	}
+	return n
+}
+
+// Print writes the grid to w, one line per row, with each cell turned into
+// text by format.
+func (g *Grid[T]) Print(w io.Writer, format func(T) string) error {
+	bw := bufio.NewWriter(w)
+
+	for y := 0; y < g.Height; y++ {
+		for _, c := range g.Row(y) {
+			bw.WriteString(format(c))
+		}
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
+
+// Parse reads a grid with one row per line and one byte per cell, which
+// cell turns into a value. All lines must have the same length. A carriage
+// return at the end of a line is ignored.
+func Parse[T any](r io.Reader, cell func(b byte) (T, error)) (*Grid[T], error) {
+	var g Grid[T]
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, parse.MaxLineLength)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			line = line[:n-1]
+		}
+
+		if g.Height == 0 {
+			g.Width = len(line)
+		} else if len(line) != g.Width {
+			return nil, fmt.Errorf("line %d: expected %d cells, got %d", g.Height+1, g.Width, len(line))
+		}
+
+		for i, b := range line {
+			v, err := cell(b)
+			if err != nil {
+				return nil, fmt.Errorf("line %d, column %d: %w", g.Height+1, i+1, err)
+			}
+			g.Cells = append(g.Cells, v)
+		}
+
+		g.Height++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(g.Cells) == 0 {
+		return nil, errors.New("empty grid")
+	}
+
+	return &g, nil
+}
+
+// Digit is a cell parser for grids of decimal digits.
+func Digit(b byte) (int, error) {
+	if b < '0' || b > '9' {
+		return 0, fmt.Errorf("invalid digit %q", b)
+	}
+	return int(b - '0'), nil
+}
@@ -0,0 +1,121 @@
+package grid
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"codeberg.org/mhofmann/adventofcode/internal/geom"
+)
+
+func parseDigits(t *testing.T, s string) *Grid[int] {
+	t.Helper()
+
+	g, err := Parse(strings.NewReader(s), Digit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g := parseDigits(t, "123\r\n456\n")
+
+	if g.Width != 3 || g.Height != 2 {
+		t.Fatalf("got size %dx%d, want 3x2", g.Width, g.Height)
+	}
+
+	if want := []int{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(g.Cells, want) {
+		t.Errorf("got cells %v, want %v", g.Cells, want)
+	}
+
+	for _, test := range []struct{ input, err string }{
+		{"", "empty grid"},
+		{"12\n345\n", "line 2: expected 2 cells, got 3"},
+		{"12\n3x\n", `line 2, column 2: invalid digit 'x'`},
+	} {
+		_, err := Parse(strings.NewReader(test.input), Digit)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Parse(%q): got error %v, want %q", test.input, err, test.err)
+		}
+	}
+}
+
+func TestAccess(t *testing.T) {
+	g := parseDigits(t, "12\n34\n")
+
+	if v, ok := g.Get(geom.Pt(1, 1)); !ok || v != 4 {
+		t.Errorf("Get(1,1) = %d, %v, want 4, true", v, ok)
+	}
+
+	if _, ok := g.Get(geom.Pt(2, 0)); ok {
+		t.Error("Get(2,0) is within the grid")
+	}
+
+	if g.Set(geom.Pt(-1, 0), 9) {
+		t.Error("Set(-1,0) is within the grid")
+	}
+
+	*g.Ref(geom.Pt(0, 1)) = 7
+	if v := g.At(geom.Pt(0, 1)); v != 7 {
+		t.Errorf("At(0,1) = %d after setting it through Ref, want 7", v)
+	}
+
+	if p := g.Point(3); p != geom.Pt(1, 1) {
+		t.Errorf("Point(3) = %v, want {1 1}", p)
+	}
+
+	if p, ok := g.Find(func(v int) bool { return v > 2 }); !ok || p != geom.Pt(0, 1) {
+		t.Errorf("Find(> 2) = %v, %v, want {0 1}, true", p, ok)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New[int](3, 3)
+
+	tests := []struct {
+		p      Point
+		n4, n8 int
+	}{
+		{geom.Pt(0, 0), 2, 3},
+		{geom.Pt(1, 0), 3, 5},
+		{geom.Pt(1, 1), 4, 8},
+		{geom.Pt(2, 2), 2, 3},
+	}
+
+	for _, test := range tests {
+		if n := len(g.Neighbors4(test.p)); n != test.n4 {
+			t.Errorf("%v has %d orthogonal neighbors, want %d", test.p, n, test.n4)
+		}
+		if n := len(g.Neighbors8(test.p)); n != test.n8 {
+			t.Errorf("%v has %d neighbors, want %d", test.p, n, test.n8)
+		}
+	}
+}
+
+func TestViews(t *testing.T) {
+	g := parseDigits(t, "123\n456\n")
+
+	if got := g.Row(1); !reflect.DeepEqual(got, []int{4, 5, 6}) {
+		t.Errorf("Row(1) = %v", got)
+	}
+
+	if got := g.Column(2); !reflect.DeepEqual(got, []int{3, 6}) {
+		t.Errorf("Column(2) = %v", got)
+	}
+
+	tr := g.Transpose()
+	if tr.Width != 2 || tr.Height != 3 || !reflect.DeepEqual(tr.Cells, []int{1, 4, 2, 5, 3, 6}) {
+		t.Errorf("Transpose() = %+v", tr)
+	}
+
+	var sb strings.Builder
+	if err := tr.Print(&sb, strconv.Itoa); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "14\n25\n36\n"; sb.String() != want {
+		t.Errorf("Print() wrote %q, want %q", sb.String(), want)
+	}
+}
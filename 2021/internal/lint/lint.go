@@ -0,0 +1,218 @@
+// Package lint checks puzzle inputs for problems before they are solved, and
+// reports them with their position in the input.
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"codeberg.org/mhofmann/adventofcode/internal/parse"
+)
+
+// Diagnostic is a problem found in an input.
+type Diagnostic struct {
+	Line int // Counting from 1, or 0 if the problem concerns the whole input
+	Col  int // Counting from 1, or 0 if the problem concerns the whole line
+	Msg  string
+}
+
+func (d Diagnostic) String() string {
+	switch {
+	case d.Line == 0:
+		return d.Msg
+	case d.Col == 0:
+		return fmt.Sprintf("%d: %s", d.Line, d.Msg)
+	default:
+		return fmt.Sprintf("%d:%d: %s", d.Line, d.Col, d.Msg)
+	}
+}
+
+// Errorf returns a diagnostic for the given position.
+func Errorf(line, col int, format string, args ...any) Diagnostic {
+	return Diagnostic{Line: line, Col: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Sort orders diagnostics by their position.
+func Sort(diags []Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Line != diags[j].Line {
+			return diags[i].Line < diags[j].Line
+		}
+		return diags[i].Col < diags[j].Col
+	})
+}
+
+// repeated reports the first occurrence of a problem and how often it
+// occurs, instead of flooding the output with one diagnostic per line.
+type repeated struct {
+	first Diagnostic
+	count int
+}
+
+func (r *repeated) add(d Diagnostic) {
+	if r.count == 0 {
+		r.first = d
+	}
+	r.count++
+}
+
+func (r *repeated) report(diags []Diagnostic) []Diagnostic {
+	switch r.count {
+	case 0:
+		return diags
+	case 1:
+		return append(diags, r.first)
+	default:
+		d := r.first
+		d.Msg += fmt.Sprintf(" (and %d more lines)", r.count-1)
+		return append(diags, d)
+	}
+}
+
+// Common checks problems independent of the puzzle: carriage returns, bytes
+// that are not printable ASCII, trailing white space, blank lines at the end
+// and a missing line feed after the last line.
+func Common(input []byte) []Diagnostic {
+	if len(input) == 0 {
+		return []Diagnostic{{Msg: "empty input"}}
+	}
+
+	var (
+		diags                 []Diagnostic
+		crlf, bad, whitespace repeated
+	)
+
+	lines := bytes.Split(input, []byte("\n"))
+	last := len(lines)
+
+	if len(lines[last-1]) == 0 {
+		lines = lines[:last-1]
+		last--
+	} else {
+		diags = append(diags, Errorf(last, len(lines[last-1])+1, "missing line feed at end of input"))
+	}
+
+	for i, line := range lines {
+		num := i + 1
+
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			crlf.add(Errorf(num, n, "line ends with CR LF instead of LF"))
+			line = line[:n-1]
+		}
+
+		for col, b := range line {
+			if (b < ' ' && b != '\t') || b > '~' {
+				bad.add(Errorf(num, col+1, "unexpected byte 0x%02x", b))
+				break
+			}
+		}
+
+		if trimmed := bytes.TrimRight(line, " \t"); len(trimmed) < len(line) && len(trimmed) > 0 {
+			whitespace.add(Errorf(num, len(trimmed)+1, "trailing white space"))
+		}
+	}
+
+	for last > 1 && len(bytes.TrimSpace(lines[last-1])) == 0 {
+		last--
+	}
+	if last < len(lines) {
+		diags = append(diags, Errorf(last+1, 0, "blank line at end of input"))
+	}
+
+	diags = crlf.report(diags)
+	diags = bad.report(diags)
+	diags = whitespace.report(diags)
+
+	return diags
+}
+
+// Lines splits input into lines for the grammar checks, ignoring carriage
+// returns and trailing blank lines, which Common reports.
+func Lines(input []byte) []parse.Line {
+	lines, _ := parse.Lines(bytes.NewReader(input))
+
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1].Text) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// Paragraphs splits input into blocks separated by blank lines.
+func Paragraphs(input []byte) [][]parse.Line {
+	paras, _ := parse.Paragraphs(bytes.NewReader(input))
+	return paras
+}
+
+// Pattern checks that each line matches re, which should be anchored at both
+// ends. For a line that does not match, it points to the first byte not in
+// chars, the bytes the grammar allows at all, or else to the whole line.
+// Want describes the expected format for the diagnostic.
+func Pattern(lines []parse.Line, re *regexp.Regexp, chars, want string) []Diagnostic {
+	var diags []Diagnostic
+
+	for _, l := range lines {
+		if re.MatchString(l.Text) {
+			continue
+		}
+
+		if col := strings.IndexFunc(l.Text, func(r rune) bool { return !strings.ContainsRune(chars, r) }); col >= 0 {
+			diags = append(diags, Errorf(l.Num, col+1, "unexpected %q, expected %s", l.Text[col], want))
+			continue
+		}
+
+		diags = append(diags, Errorf(l.Num, 0, "%q is not %s", l.Text, want))
+	}
+
+	return diags
+}
+
+// Grid checks that the lines form a rectangle of bytes from chars. Width and
+// height are checked if they are not zero, otherwise all lines must have the
+// length of the first one.
+func Grid(lines []parse.Line, chars string, width, height int) []Diagnostic {
+	var diags []Diagnostic
+
+	if len(lines) == 0 {
+		return []Diagnostic{{Msg: "empty grid"}}
+	}
+
+	if height > 0 && len(lines) != height {
+		diags = append(diags, Errorf(lines[len(lines)-1].Num, 0, "grid has %d rows instead of %d", len(lines), height))
+	}
+
+	if width == 0 {
+		width = len(lines[0].Text)
+	}
+
+	for _, l := range lines {
+		if len(l.Text) != width {
+			diags = append(diags, Errorf(l.Num, 0, "row has %d cells instead of %d", len(l.Text), width))
+		}
+
+		for col := 0; col < len(l.Text); col++ {
+			if !strings.ContainsRune(chars, rune(l.Text[col])) {
+				diags = append(diags, Errorf(l.Num, col+1, "unexpected %q in grid", l.Text[col]))
+				break
+			}
+		}
+	}
+
+	return diags
+}
+
+// Count checks that there are between min and max lines, where max is not
+// checked if it is zero.
+func Count(lines []parse.Line, min, max int, what string) []Diagnostic {
+	switch {
+	case len(lines) < min:
+		return []Diagnostic{{Msg: fmt.Sprintf("expected at least %d %s, got %d", min, what, len(lines))}}
+	case max > 0 && len(lines) > max:
+		return []Diagnostic{Errorf(lines[max].Num, 0, "expected at most %d %s, got %d", max, what, len(lines))}
+	default:
+		return nil
+	}
+}
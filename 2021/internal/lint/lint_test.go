@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func messages(diags []Diagnostic) []string {
+	var s []string
+	for _, d := range diags {
+		s = append(s, d.String())
+	}
+	return s
+}
+
+func TestCommon(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"1\n2\n", nil},
+		{"", []string{"empty input"}},
+		{"1\n2", []string{"2:2: missing line feed at end of input"}},
+		{"1\r\n2\r\n3\n", []string{"1:2: line ends with CR LF instead of LF (and 1 more lines)"}},
+		{"1\n\n\n", []string{"2: blank line at end of input"}},
+		{"a \nb\t\n", []string{"1:2: trailing white space (and 1 more lines)"}},
+		{"a\x00b\n", []string{"1:2: unexpected byte 0x00"}},
+	}
+
+	for _, test := range tests {
+		if got := messages(Common([]byte(test.input))); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Common(%q) = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestPattern(t *testing.T) {
+	rx := regexp.MustCompile(`^\d+,\d+$`)
+	lines := Lines([]byte("1,2\n3;4\n5,\n\n"))
+
+	got := messages(Pattern(lines, rx, "0123456789,", `"x,y"`))
+	want := []string{
+		`2:2: unexpected ';', expected "x,y"`,
+		`3: "5," is not "x,y"`,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	tests := []struct {
+		input         string
+		width, height int
+		want          []string
+	}{
+		{"123\n456\n", 0, 0, nil},
+		{"123\n456\n", 3, 2, nil},
+		{"123\n45\n", 0, 0, []string{"2: row has 2 cells instead of 3"}},
+		{"123\n4x6\n", 0, 0, []string{"2:2: unexpected 'x' in grid"}},
+		{"12\n34\n", 3, 3, []string{"1: row has 2 cells instead of 3", "2: grid has 2 rows instead of 3", "2: row has 2 cells instead of 3"}},
+		{"", 0, 0, []string{"empty grid"}},
+	}
+
+	for _, test := range tests {
+		diags := Grid(Lines([]byte(test.input)), "0123456789", test.width, test.height)
+		Sort(diags)
+
+		if got := messages(diags); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Grid(%q, %d, %d) = %q, want %q", test.input, test.width, test.height, got, test.want)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	lines := Lines([]byte("a\nb\nc\n"))
+
+	if got := Count(lines, 1, 3, "lines"); got != nil {
+		t.Errorf("got %v, want no diagnostics", got)
+	}
+
+	if got := messages(Count(lines, 4, 0, "lines")); !reflect.DeepEqual(got, []string{"expected at least 4 lines, got 3"}) {
+		t.Errorf("got %q", got)
+	}
+
+	if got := messages(Count(lines, 1, 1, "lines")); !reflect.DeepEqual(got, []string{"2: expected at most 1 lines, got 3"}) {
+		t.Errorf("got %q", got)
+	}
+}
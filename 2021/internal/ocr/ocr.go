@@ -0,0 +1,153 @@
+// Package ocr reads the capital letters that some puzzles draw with dots,
+// like the code on the folded paper of day 13.
+package ocr
+
+import (
+	"fmt"
+	"strings"
+
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+)
+
+// Font describes letters of a fixed size that are drawn side by side.
+type Font struct {
+	Name    string
+	Width   int // Of each letter, in dots
+	Height  int
+	Spacing int    // Empty columns between two letters
+	Letters string // That the font can draw, in alphabetical order
+
+	glyphs map[string]rune
+}
+
+// newFont returns a font of the given size whose letters are drawn next to
+// each other in art, like they would appear in a puzzle.
+func newFont(name string, width, height, spacing int, letters, art string) *Font {
+	f := &Font{Name: name, Width: width, Height: height, Spacing: spacing, Letters: letters, glyphs: make(map[string]rune)}
+
+	rows := strings.Fields(art)
+	if len(rows) != height {
+		panic(fmt.Sprintf("ocr: font %s has %d rows instead of %d", name, len(rows), height))
+	}
+
+	for i, r := range letters {
+		x := i * (width + spacing)
+
+		var sb strings.Builder
+		for _, row := range rows {
+			sb.WriteString(row[x : x+width])
+		}
+
+		if _, dup := f.glyphs[sb.String()]; dup {
+			panic(fmt.Sprintf("ocr: font %s draws %c like another letter", name, r))
+		}
+		f.glyphs[sb.String()] = r
+	}
+
+	return f
+}
+
+// Small is the font of 4×6 dots used by most puzzles.
+var Small = newFont("4×6", 4, 6, 1, "ABCEFGHIJKLOPRSUYZ", `
+	.##..###...##..####.####..##..#..#..###...##.#..#.#.....##..###..###...###.#..#.#....####
+	#..#.#..#.#..#.#....#....#..#.#..#...#.....#.#.#..#....#..#.#..#.#..#.#....#..#.#.......#
+	#..#.###..#....###..###..#....####...#.....#.##...#....#..#.#..#.#..#.#....#..#..#.#...#.
+	####.#..#.#....#....#....#.##.#..#...#.....#.#.#..#....#..#.###..###...##..#..#...#...#..
+	#..#.#..#.#..#.#....#....#..#.#..#...#..#..#.#.#..#....#..#.#....#.#.....#.#..#...#..#...
+	#..#.###...##..####.#.....###.#..#..###..##..#..#.####..##..#....#..#.###...##....#..####
+`)
+
+// Large is the font of 6×10 dots used by a few puzzles of other years.
+var Large = newFont("6×10", 6, 10, 2, "ABCEFGHJKLNPRXZ", `
+	..##....#####....####...######..######...####...#....#.....###..#....#..#.......#....#..#####...#####...#....#..######
+	.#..#...#....#..#....#..#.......#.......#....#..#....#......#...#...#...#.......##...#..#....#..#....#..#....#.......#
+	#....#..#....#..#.......#.......#.......#.......#....#......#...#..#....#.......##...#..#....#..#....#...#..#........#
+	#....#..#....#..#.......#.......#.......#.......#....#......#...#.#.....#.......#.#..#..#....#..#....#...#..#.......#.
+	#....#..#####...#.......#####...#####...#.......######......#...##......#.......#.#..#..#####...#####.....##.......#..
+	######..#....#..#.......#.......#.......#..###..#....#......#...##......#.......#..#.#..#.......#..#......##......#...
+	#....#..#....#..#.......#.......#.......#....#..#....#......#...#.#.....#.......#..#.#..#.......#...#....#..#....#....
+	#....#..#....#..#.......#.......#.......#....#..#....#..#...#...#..#....#.......#...##..#.......#...#....#..#...#.....
+	#....#..#....#..#....#..#.......#.......#...##..#....#..#...#...#...#...#.......#...##..#.......#....#..#....#..#.....
+	#....#..#####....####...######..#........###.#..#....#...###....#....#..######..#....#..#.......#....#..#....#..######
+`)
+
+// Fonts are the fonts Read tries.
+var Fonts = []*Font{Small, Large}
+
+// Read returns the letters drawn by the cells of g that are not zero, in the
+// font as high as g. The first letter must start in the left column of g.
+func Read(g *grid.Grid[uint8]) (string, error) {
+	for _, f := range Fonts {
+		if f.Height == g.Height {
+			return f.Read(g)
+		}
+	}
+
+	return "", fmt.Errorf("no font is %d dots high", g.Height)
+}
+
+// Read returns the letters drawn in f by the cells of g that are not zero.
+// The first letter must start in the left column of g.
+func (f *Font) Read(g *grid.Grid[uint8]) (string, error) {
+	if g.Height != f.Height {
+		return "", fmt.Errorf("%d rows instead of %d for font %s", g.Height, f.Height, f.Name)
+	}
+
+	var (
+		text  strings.Builder
+		glyph strings.Builder
+	)
+
+	for x0 := 0; x0 < g.Width; x0 += f.Width + f.Spacing {
+		glyph.Reset()
+
+		for y := 0; y < f.Height; y++ {
+			for x := x0; x < x0+f.Width; x++ {
+				if v, _ := g.Get(grid.Point{X: x, Y: y}); v != 0 {
+					glyph.WriteByte('#')
+				} else {
+					glyph.WriteByte('.')
+				}
+			}
+		}
+
+		r, ok := f.glyphs[glyph.String()]
+		if !ok {
+			return "", fmt.Errorf("unknown letter %d in font %s", text.Len()+1, f.Name)
+		}
+		text.WriteRune(r)
+	}
+
+	return text.String(), nil
+}
+
+// Draw returns a grid with cells of 1 for the dots of text in f, the
+// opposite of Read.
+func (f *Font) Draw(text string) (*grid.Grid[uint8], error) {
+	glyphs := make(map[rune]string, len(f.glyphs))
+	for glyph, r := range f.glyphs {
+		glyphs[r] = glyph
+	}
+
+	var width int
+	if n := len([]rune(text)); n > 0 {
+		width = n*(f.Width+f.Spacing) - f.Spacing
+	}
+
+	g := grid.New[uint8](width, f.Height)
+
+	for i, r := range []rune(text) {
+		glyph, ok := glyphs[r]
+		if !ok {
+			return nil, fmt.Errorf("font %s has no letter %q", f.Name, r)
+		}
+
+		for j := range glyph {
+			if glyph[j] == '#' {
+				g.Set(grid.Point{X: i*(f.Width+f.Spacing) + j%f.Width, Y: j / f.Width}, 1)
+			}
+		}
+	}
+
+	return g, nil
+}
@@ -0,0 +1,65 @@
+package ocr
+
+import (
+	"testing"
+
+	"codeberg.org/mhofmann/adventofcode/internal/grid"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, f := range Fonts {
+		var letters []rune
+		for _, r := range f.glyphs {
+			letters = append(letters, r)
+		}
+
+		for _, text := range []string{string(letters), "", string(letters[:1])} {
+			g, err := f.Draw(text)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, err := Read(g); err != nil || got != text {
+				t.Errorf("font %s: got %q, %v, want %q", f.Name, got, err, text)
+			}
+		}
+	}
+}
+
+func TestReadPadded(t *testing.T) {
+	g, err := Small.Draw("HI")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The paper of day 13 includes the empty column after the last letter
+	padded := grid.New[uint8](g.Width+1, g.Height)
+	for i, v := range g.Cells {
+		padded.Set(g.Point(i), v)
+	}
+
+	if got, err := Read(padded); err != nil || got != "HI" {
+		t.Errorf("got %q, %v, want HI", got, err)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	square := grid.New[uint8](5, 5)
+	if _, err := Read(square); err == nil {
+		t.Error("got no error for a grid without a font of its height")
+	}
+
+	g, err := Small.Draw("ABC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Set(grid.Point{X: 6, Y: 1}, 1)
+
+	if _, err := Read(g); err == nil || err.Error() != "unknown letter 2 in font 4×6" {
+		t.Errorf("got error %v, want one for letter 2", err)
+	}
+
+	if _, err := Small.Draw("W"); err == nil {
+		t.Error("got no error for a missing letter")
+	}
+}
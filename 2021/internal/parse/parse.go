@@ -0,0 +1,212 @@
+// Package parse helps reading puzzle inputs and reporting where they are
+// malformed.
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Error is an error at a line of the input.
+type Error struct {
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Line is a line of input without its line ending, along with its number,
+// counting from 1.
+type Line struct {
+	Num  int
+	Text string
+}
+
+// Errorf returns an Error for l.
+func (l Line) Errorf(format string, args ...any) error {
+	return &Error{Line: l.Num, Err: fmt.Errorf(format, args...)}
+}
+
+// Wrap returns err as an Error for l, or nil if err is nil.
+func (l Line) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Line: l.Num, Err: err}
+}
+
+// Scanf parses the line according to format like fmt.Sscanf, but also
+// fails if text other than white space remains.
+func (l Line) Scanf(format string, args ...any) error {
+	r := strings.NewReader(l.Text)
+
+	if _, err := fmt.Fscanf(r, format, args...); err != nil {
+		return l.Errorf("%q does not match %q: %w", l.Text, format, err)
+	}
+
+	rest, _ := io.ReadAll(r)
+	if s := strings.TrimSpace(string(rest)); s != "" {
+		return l.Errorf("unexpected %q at the end", s)
+	}
+
+	return nil
+}
+
+// Ints returns the integers in the line. See Ints.
+func (l Line) Ints() []int {
+	return Ints(l.Text)
+}
+
+// IntList parses the line as a list of integers. See IntList.
+func (l Line) IntList() ([]int, error) {
+	ints, err := IntList(l.Text)
+	return ints, l.Wrap(err)
+}
+
+// MaxLineLength is the longest line Lines accepts. Generated inputs can
+// have lines much longer than the default limit of bufio.Scanner.
+const MaxLineLength = 1 << 26
+
+// Lines reads all lines from r. A carriage return before a line feed is
+// removed along with it.
+func Lines(r io.Reader) ([]Line, error) {
+	var lines []Line
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, MaxLineLength)
+
+	for n := 1; scanner.Scan(); n++ {
+		lines = append(lines, Line{Num: n, Text: strings.TrimSuffix(scanner.Text(), "\r")})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// Paragraphs reads all lines from r and splits them into blocks separated by
+// one or more blank lines. Lines holding only white space count as blank.
+func Paragraphs(r io.Reader) ([][]Line, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		paras [][]Line
+		para  []Line
+	)
+
+	for _, l := range lines {
+		if strings.TrimSpace(l.Text) == "" {
+			if len(para) > 0 {
+				paras = append(paras, para)
+				para = nil
+			}
+			continue
+		}
+
+		para = append(para, l)
+	}
+
+	if len(para) > 0 {
+		paras = append(paras, para)
+	}
+
+	return paras, nil
+}
+
+// Ints returns all integers found in s, ignoring any other text. A minus
+// sign directly before a number makes it negative. Numbers too large for an
+// int are skipped.
+func Ints(s string) []int {
+	var ints []int
+
+	for i := 0; i < len(s); {
+		if !isDigit(s[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		if start > 0 && s[start-1] == '-' {
+			start--
+		}
+
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+
+		if n, err := strconv.Atoi(s[start:i]); err == nil {
+			ints = append(ints, n)
+		}
+	}
+
+	return ints
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// IntList parses s as a list of integers separated by commas, white space or
+// both. Empty fields between commas are not allowed.
+func IntList(s string) ([]int, error) {
+	var ints []int
+
+	fields := strings.Split(s, ",")
+	if strings.TrimSpace(s) == "" {
+		fields = nil
+	}
+
+	for i, field := range fields {
+		words := strings.FieldsFunc(field, unicode.IsSpace)
+
+		if len(words) == 0 {
+			return nil, fmt.Errorf("empty field %d in list", i+1)
+		}
+
+		for _, w := range words {
+			n, err := strconv.Atoi(w)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q in list", w)
+			}
+			ints = append(ints, n)
+		}
+	}
+
+	return ints, nil
+}
+
+// ReadIntList reads a list of integers from r like IntList, allowing the list
+// to span multiple lines.
+func ReadIntList(r io.Reader) ([]int, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var ints []int
+
+	for _, l := range lines {
+		list, err := l.IntList()
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, list...)
+	}
+
+	return ints, nil
+}
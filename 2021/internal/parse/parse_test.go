@@ -0,0 +1,101 @@
+package parse
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []int
+	}{
+		{"", nil},
+		{"target area: x=20..30, y=-10..-5", []int{20, 30, -10, -5}},
+		{"0,9 -> 5,9", []int{0, 9, 5, 9}},
+		{"a-b 12-3", []int{12, -3}},
+		{"99999999999999999999 1", []int{1}},
+	}
+
+	for _, test := range tests {
+		if got := Ints(test.s); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Ints(%q) = %v, want %v", test.s, got, test.want)
+		}
+	}
+}
+
+func TestIntList(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []int
+		err  string
+	}{
+		{"3,4,3,1,2", []int{3, 4, 3, 1, 2}, ""},
+		{" 1  2\t-3 ", []int{1, 2, -3}, ""},
+		{"1, 2 ,3", []int{1, 2, 3}, ""},
+		{"", nil, ""},
+		{"1,,2", nil, "empty field 2 in list"},
+		{"1,x", nil, `invalid number "x" in list`},
+	}
+
+	for _, test := range tests {
+		got, err := IntList(test.s)
+
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("IntList(%q): got error %v, want %q", test.s, err, test.err)
+			}
+			continue
+		}
+
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("IntList(%q) = %v, %v, want %v", test.s, got, err, test.want)
+		}
+	}
+}
+
+func TestReadIntList(t *testing.T) {
+	_, err := ReadIntList(strings.NewReader("1,2\n3,4\n5,,6\n"))
+
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Line != 3 {
+		t.Errorf("got error %v, want an error in line 3", err)
+	}
+}
+
+func TestParagraphs(t *testing.T) {
+	paras, err := Paragraphs(strings.NewReader("\na\nb\r\n\n \n\nc\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]Line{
+		{{2, "a"}, {3, "b"}},
+		{{7, "c"}},
+	}
+
+	if !reflect.DeepEqual(paras, want) {
+		t.Errorf("got %v, want %v", paras, want)
+	}
+}
+
+func TestScanf(t *testing.T) {
+	var a, b int
+
+	l := Line{Num: 4, Text: "x=1..2 "}
+	if err := l.Scanf("x=%d..%d", &a, &b); err != nil || a != 1 || b != 2 {
+		t.Errorf("got %d, %d, %v, want 1, 2, nil", a, b, err)
+	}
+
+	l.Text = "x=1..2, y=3"
+	if err := l.Scanf("x=%d..%d", &a, &b); err == nil || err.Error() != `line 4: unexpected ", y=3" at the end` {
+		t.Errorf("got error %v for trailing text", err)
+	}
+
+	l.Text = "x=1"
+	if err := l.Scanf("x=%d..%d", &a, &b); err == nil || !strings.HasPrefix(err.Error(), "line 4: ") {
+		t.Errorf("got error %v for missing text", err)
+	}
+}
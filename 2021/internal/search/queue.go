@@ -0,0 +1,59 @@
+package search
+
+// PriorityQueue holds values ordered by priority, with the lowest priority
+// coming out first. The zero value is an empty queue.
+type PriorityQueue[T any] struct {
+	items []item[T]
+}
+
+type item[T any] struct {
+	value    T
+	priority int
+}
+
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items)
+}
+
+func (q *PriorityQueue[T]) Push(v T, priority int) {
+	q.items = append(q.items, item[T]{v, priority})
+
+	// Sift up
+	i := len(q.items) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if q.items[parent].priority <= q.items[i].priority {
+			break
+		}
+		q.items[i], q.items[parent] = q.items[parent], q.items[i]
+		i = parent
+	}
+}
+
+// Pop removes the value with the lowest priority from the queue and returns
+// it along with its priority. The queue must not be empty.
+func (q *PriorityQueue[T]) Pop() (T, int) {
+	top := q.items[0]
+	n := len(q.items) - 1
+	q.items[0] = q.items[n]
+	q.items[n] = item[T]{}
+	q.items = q.items[:n]
+
+	// Sift down
+	i := 0
+	for {
+		min := i
+		for _, c := range [2]int{2*i + 1, 2*i + 2} {
+			if c < n && q.items[c].priority < q.items[min].priority {
+				min = c
+			}
+		}
+		if min == i {
+			break
+		}
+		q.items[i], q.items[min] = q.items[min], q.items[i]
+		i = min
+	}
+
+	return top.value, top.priority
+}
@@ -0,0 +1,34 @@
+package search
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	var (
+		q    PriorityQueue[string]
+		want []int
+	)
+
+	for i := 0; i < 100; i++ {
+		p := rng.Intn(50)
+		q.Push("x", p)
+		want = append(want, p)
+	}
+
+	sort.Ints(want)
+
+	for i, w := range want {
+		if _, p := q.Pop(); p != w {
+			t.Fatalf("pop %d: got priority %d, want %d", i, p, w)
+		}
+	}
+
+	if q.Len() != 0 {
+		t.Errorf("queue holds %d values after popping all", q.Len())
+	}
+}
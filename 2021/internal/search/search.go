@@ -0,0 +1,192 @@
+// Package search finds paths through graphs given as functions that list the
+// neighbors of a node.
+package search
+
+// Search describes a graph and when to stop exploring it. Only Neighbors is
+// required.
+type Search[N comparable] struct {
+	// Neighbors returns the nodes reachable from n in one step.
+	Neighbors func(n N) []N
+
+	// Cost returns the cost of the step from one node to a neighbor. If
+	// it is nil, every step costs 1.
+	Cost func(from, to N) int
+
+	// Heuristic estimates the cost from n to the nearest goal for A*. It
+	// must never overestimate.
+	Heuristic func(n N) int
+
+	// Goal reports whether n is a goal. The search ends at the first goal
+	// found. If Goal is nil, the search explores every reachable node.
+	Goal func(n N) bool
+
+	// Visit is called once for each node when its cost is final. The
+	// search stops if it returns false.
+	Visit func(n N, cost int) bool
+}
+
+// Result holds the nodes reached by a search and how to get there.
+type Result[N comparable] struct {
+	Found bool // Whether a goal was reached
+	Goal  N
+	Cost  int // Cost of the path to Goal
+
+	costs map[N]int
+	prev  map[N]N
+}
+
+func newResult[N comparable]() *Result[N] {
+	return &Result[N]{costs: make(map[N]int), prev: make(map[N]N)}
+}
+
+// CostTo returns the cost of the cheapest path found to n and whether n was
+// reached at all.
+func (r *Result[N]) CostTo(n N) (int, bool) {
+	c, ok := r.costs[n]
+	return c, ok
+}
+
+// PathTo returns the nodes on the cheapest path found from a start node to n,
+// including both, or nil if n was not reached.
+func (r *Result[N]) PathTo(n N) []N {
+	if _, ok := r.costs[n]; !ok {
+		return nil
+	}
+
+	path := []N{n}
+
+	for {
+		p, ok := r.prev[n]
+		if !ok {
+			break
+		}
+		path = append(path, p)
+		n = p
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// Path returns the path to the goal, or nil if no goal was found.
+func (r *Result[N]) Path() []N {
+	if !r.Found {
+		return nil
+	}
+	return r.PathTo(r.Goal)
+}
+
+// done checks whether the search ends at n, which was just reached with its
+// final cost.
+func (s *Search[N]) done(r *Result[N], n N, cost int) bool {
+	if s.Visit != nil && !s.Visit(n, cost) {
+		return true
+	}
+
+	if s.Goal != nil && s.Goal(n) {
+		r.Found, r.Goal, r.Cost = true, n, cost
+		return true
+	}
+
+	return false
+}
+
+// BFS searches breadth-first from the start nodes, ignoring Cost and
+// Heuristic. The cost of a path is its number of steps.
+func (s *Search[N]) BFS(start ...N) *Result[N] {
+	r := newResult[N]()
+
+	queue := make([]N, 0, len(start))
+	for _, n := range start {
+		if _, ok := r.costs[n]; !ok {
+			r.costs[n] = 0
+			queue = append(queue, n)
+		}
+	}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		cost := r.costs[n]
+
+		if s.done(r, n, cost) {
+			return r
+		}
+
+		for _, next := range s.Neighbors(n) {
+			if _, ok := r.costs[next]; !ok {
+				r.costs[next] = cost + 1
+				r.prev[next] = n
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return r
+}
+
+// Dijkstra searches for the cheapest paths from the start nodes, ignoring
+// Heuristic. Costs must not be negative.
+func (s *Search[N]) Dijkstra(start ...N) *Result[N] {
+	return s.bestFirst(start, func(N) int { return 0 })
+}
+
+// AStar searches for the cheapest path from the start nodes to a goal, using
+// Heuristic to explore promising nodes first. Costs must not be negative.
+func (s *Search[N]) AStar(start ...N) *Result[N] {
+	h := s.Heuristic
+	if h == nil {
+		h = func(N) int { return 0 }
+	}
+	return s.bestFirst(start, h)
+}
+
+func (s *Search[N]) bestFirst(start []N, h func(N) int) *Result[N] {
+	r := newResult[N]()
+	closed := make(map[N]bool)
+
+	var q PriorityQueue[N]
+
+	for _, n := range start {
+		r.costs[n] = 0
+		q.Push(n, h(n))
+	}
+
+	for q.Len() > 0 {
+		n, _ := q.Pop()
+		if closed[n] {
+			continue
+		}
+		closed[n] = true
+
+		cost := r.costs[n]
+
+		if s.done(r, n, cost) {
+			return r
+		}
+
+		for _, next := range s.Neighbors(n) {
+			if closed[next] {
+				continue
+			}
+
+			c := cost + 1
+			if s.Cost != nil {
+				c = cost + s.Cost(n, next)
+			}
+
+			if old, ok := r.costs[next]; ok && old <= c {
+				continue
+			}
+
+			r.costs[next] = c
+			r.prev[next] = n
+			q.Push(next, c+h(next))
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,140 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+// maze is a grid where '#' is a wall and digits are the cost of entering a
+// cell, with '.' costing 1.
+var maze = []string{
+	"S.#....",
+	".9#.##.",
+	"...#...",
+	".#...#G",
+}
+
+type pos struct{ x, y int }
+
+func mazeSearch() *Search[pos] {
+	cell := func(p pos) byte {
+		if p.y < 0 || p.y >= len(maze) || p.x < 0 || p.x >= len(maze[p.y]) {
+			return '#'
+		}
+		return maze[p.y][p.x]
+	}
+
+	return &Search[pos]{
+		Neighbors: func(p pos) []pos {
+			var n []pos
+			for _, d := range []pos{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+				q := pos{p.x + d.x, p.y + d.y}
+				if cell(q) != '#' {
+					n = append(n, q)
+				}
+			}
+			return n
+		},
+		Cost: func(from, to pos) int {
+			if c := cell(to); c >= '0' && c <= '9' {
+				return int(c - '0')
+			}
+			return 1
+		},
+		Heuristic: func(p pos) int {
+			return abs(6-p.x) + abs(3-p.y)
+		},
+		Goal: func(p pos) bool { return cell(p) == 'G' },
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func TestSearch(t *testing.T) {
+	s := mazeSearch()
+	start := pos{0, 0}
+
+	tests := []struct {
+		name   string
+		search func(...pos) *Result[pos]
+	}{
+		{"BFS", s.BFS},
+		{"Dijkstra", s.Dijkstra},
+		{"AStar", s.AStar},
+	}
+
+	for _, test := range tests {
+		r := test.search(start)
+		if !r.Found {
+			t.Errorf("%s: goal not found", test.name)
+			continue
+		}
+
+		if r.Cost != 11 {
+			t.Errorf("%s: got cost %d, want 11", test.name, r.Cost)
+		}
+
+		path := r.Path()
+		if len(path) != 12 || path[0] != start || path[len(path)-1] != r.Goal {
+			t.Errorf("%s: got path %v", test.name, path)
+		}
+
+		for i := 1; i < len(path); i++ {
+			if abs(path[i].x-path[i-1].x)+abs(path[i].y-path[i-1].y) != 1 {
+				t.Errorf("%s: path %v makes a jump", test.name, path)
+				break
+			}
+		}
+	}
+}
+
+func TestSearchCosts(t *testing.T) {
+	s := mazeSearch()
+	s.Goal = nil
+
+	// Going through the 9 costs more than the detour
+	r := s.Dijkstra(pos{0, 0})
+	if c, ok := r.CostTo(pos{1, 2}); !ok || c != 3 {
+		t.Errorf("cost to 1,2: got %d, %v, want 3, true", c, ok)
+	}
+
+	if want := []pos{{0, 0}, {0, 1}, {0, 2}, {1, 2}}; !reflect.DeepEqual(r.PathTo(pos{1, 2}), want) {
+		t.Errorf("path to 1,2: got %v, want %v", r.PathTo(pos{1, 2}), want)
+	}
+
+	if c, _ := r.CostTo(pos{1, 1}); c != 10 {
+		t.Errorf("cost to 1,1: got %d, want 10", c)
+	}
+
+	if _, ok := r.CostTo(pos{2, 0}); ok {
+		t.Error("reached a wall")
+	}
+
+	if r.Found || r.Path() != nil {
+		t.Error("found a goal without a goal function")
+	}
+}
+
+func TestSearchVisit(t *testing.T) {
+	s := mazeSearch()
+
+	var visited []pos
+	s.Visit = func(p pos, cost int) bool {
+		visited = append(visited, p)
+		return cost < 2
+	}
+
+	r := s.BFS(pos{0, 0})
+	if r.Found {
+		t.Error("found the goal after stopping")
+	}
+
+	if len(visited) != 4 {
+		t.Errorf("visited %v, want the nodes up to the first at cost 2", visited)
+	}
+}
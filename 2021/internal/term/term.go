@@ -0,0 +1,22 @@
+// Package term controls the terminal for interactive output, with just
+// enough of it to play animations. Linux, macOS and the BSDs are supported;
+// elsewhere, Windows included, nothing is a terminal and output falls back
+// to plain text.
+package term
+
+import "errors"
+
+// ErrUnsupported is returned on systems where terminals cannot be controlled.
+var ErrUnsupported = errors.New("terminal control is not supported on this system")
+
+// ANSI escape sequences understood by all common terminals.
+const (
+	AltScreen  = "\x1b[?1049h" // Switch to a screen of its own
+	MainScreen = "\x1b[?1049l" // Switch back and restore what was there
+	HideCursor = "\x1b[?25l"
+	ShowCursor = "\x1b[?25h"
+	Home       = "\x1b[H"  // Move the cursor to the top left corner
+	ClearLine  = "\x1b[K"  // Clear the rest of the line
+	ClearBelow = "\x1b[J"  // Clear the rest of the screen
+	Reset      = "\x1b[0m" // Reset colours
+)
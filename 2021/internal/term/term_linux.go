@@ -0,0 +1,9 @@
+package term
+
+import "syscall"
+
+// The requests that get and set the state of a terminal.
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
@@ -0,0 +1,20 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package term
+
+// IsTerminal reports whether fd refers to a terminal, which is never the
+// case on this system.
+func IsTerminal(fd int) bool {
+	return false
+}
+
+// Size returns the number of columns and rows of the terminal fd.
+func Size(fd int) (width, height int, err error) {
+	return 0, 0, ErrUnsupported
+}
+
+// Raw would pass on keys as soon as they are pressed, but is not supported
+// on this system.
+func Raw(fd int) (restore func() error, err error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,27 @@
+package term
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	if IsTerminal(int(r.Fd())) {
+		t.Error("a pipe is a terminal")
+	}
+
+	if _, err := Raw(int(r.Fd())); err == nil {
+		t.Error("got no error making a pipe raw")
+	}
+
+	if _, _, err := Size(int(r.Fd())); err == nil {
+		t.Error("got a size for a pipe")
+	}
+}